@@ -8,6 +8,7 @@ type Binding struct {
 	// Concrete is resolvable by passing a type of the concrete
 	// Abstract is our Abstract -> Concrete resolver. We provide an interface, and get a service implementation.
 	// Singleton
+	// Scoped
 	bindingType string
 
 	// The function to call when our bindingType is Function
@@ -20,11 +21,45 @@ type Binding struct {
 	// Set to true when we create this binding as a singleton
 	isSingleton bool
 
+	// Set to true when the binding was created for a value passed to Instance()
+	isInstance bool
+
+	// Set to true when we create this binding as scoped
+	// Scoped bindings are instantiated once per container that resolves them
+	isScoped bool
+
 	// Our abstract type, this is usually an interface
 	// If we only bound a concrete implementation, this will also be our concrete
 	abstractType reflect.Type
 	// This is our actually resolvable concrete type
 	concreteType reflect.Type
 
+	// The type this binding is registered under in the container, extenders are looked up by it
+	registeredType reflect.Type
+
 	invocable *Invocable
+
+	// The named bindings to use for the resolver function's args, set by passing NamedArgs when binding
+	namedArgs NamedArgs
+
+	// The tags to inject into the resolver function's slice args, set by passing InjectTagged when binding
+	taggedArgs []TaggedArg
+
+	// Set by BindWhenResolving, the binding is only used while this returns true
+	condition func() bool
+
+	// The binding a conditional binding was registered over, it's used while the condition is false
+	fallback *Binding
+}
+
+// active - The binding which should be used for this registration, when our condition is false, we try
+// the binding we were registered over. Returns nil if none of them can be used
+func (binding *Binding) active() *Binding {
+	for b := binding; b != nil; b = b.fallback {
+		if b.condition == nil || b.condition() {
+			return b
+		}
+	}
+
+	return nil
 }
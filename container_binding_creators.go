@@ -5,16 +5,16 @@ import (
 	"reflect"
 )
 
-// addFunctionBinding - Create a new container binding from the function
+// newFunctionBinding - Create a new container binding from the function
 // This resolves the return type of the function as the Abstract
 // And the functions return value is our Concrete
-func (container *ContainerInstance) addFunctionBinding(definition reflect.Type, resolver any) {
+// Returns the type the binding should be registered under
+func newFunctionBinding(definition reflect.Type, resolver any) (reflect.Type, *Binding, error) {
 	numOut := definition.NumOut()
 	if numOut == 0 {
-		log.Printf("Trying to register binding but it doesnt have a return type...")
-		return
+		return nil, nil, newError(ErrInvalidBinding, definition, "trying to register binding but it doesnt have a return type", nil)
 	}
-	if numOut > 1 {
+	if hasUnhandledReturns(definition) {
 		log.Printf("Registering a function binding with > 1 return args. Only the first arg is handled.")
 	}
 
@@ -24,7 +24,7 @@ func (container *ContainerInstance) addFunctionBinding(definition reflect.Type,
 
 	resolverType := reflect.TypeOf(resolver)
 
-	container.addBinding(indirectType(definition.Out(0)), &Binding{
+	return pointerElemType(definition.Out(0)), &Binding{
 		bindingType: "Function",
 
 		resolverFunction:   resolver,
@@ -34,18 +34,23 @@ func (container *ContainerInstance) addFunctionBinding(definition reflect.Type,
 		concreteType: resolverType,
 
 		invocable: CreateInvocableFunction(resolver),
-	})
+	}, nil
 }
 
-// addConcreteBinding - Create a new container binding from the concrete value
+// newConcreteBinding - Create a new container binding from the concrete value
 // This will set our abstract type to the concrete type and the concrete type will be our concrete type..
 // This just allows us to easily bind things to the container if we don't care about abstracts
-func (container *ContainerInstance) addConcreteBinding(definition reflect.Type, concrete any) {
+func newConcreteBinding(definition reflect.Type) (reflect.Type, *Binding, error) {
 	concreteType := definition
 	if definition.Kind() == reflect.Ptr {
 		concreteType = definition.Elem()
 	}
 
+	invocable := CreateInvocable(concreteType)
+	if invocable == nil {
+		return nil, nil, newError(ErrInvalidBinding, definition, "concrete is not a struct or function", nil)
+	}
+
 	// concreteWrapperFuncType := reflect.TypeOf(func() any {
 	// 	return concrete
 	// })
@@ -54,7 +59,7 @@ func (container *ContainerInstance) addConcreteBinding(definition reflect.Type,
 	// 	return []reflect.Value{reflect.ValueOf(concrete)}
 	// })
 
-	container.addBinding(concreteType, &Binding{
+	return concreteType, &Binding{
 		bindingType: "Concrete",
 
 		isFunctionResolver: false,
@@ -62,18 +67,204 @@ func (container *ContainerInstance) addConcreteBinding(definition reflect.Type,
 		abstractType: concreteType,
 		concreteType: definition,
 
-		invocable: CreateInvocable(concreteType),
-	})
+		invocable: invocable,
+	}, nil
+}
+
+// newAbstractBinding - Create a new container binding for the Abstract -> Concrete definition
+// The binding is registered under the abstract(interface) type
+// When concrete is a function, it's our resolver, it's called with its args injected, like a function binding
+func newAbstractBinding(definition reflect.Type, concrete any) (reflect.Type, *Binding, error) {
+	if concrete == nil {
+		return nil, nil, newError(ErrInvalidBinding, definition, "concrete binding definition is nil", nil)
+	}
+
+	concreteBindingType := getType(concrete)
+
+	if concreteBindingType.Kind() == reflect.Func {
+		return newAbstractFunctionBinding(definition, concreteBindingType, concrete)
+	}
+
+	abstractType := getAbstractReturnType(definition)
+	if abstractType == nil {
+		return nil, nil, newError(ErrInvalidBinding, definition, "failed to get type of abstract", nil)
+	}
+
+	concreteType := getConcreteReturnType(concreteBindingType)
+	if concreteType == nil {
+		return nil, nil, newError(ErrInvalidBinding, concreteBindingType, "failed to get type of concrete", nil)
+	}
+
+	invocable := CreateInvocable(concreteType)
+	if invocable == nil {
+		return nil, nil, newError(ErrInvalidBinding, concreteType, "concrete is not a struct or function", nil)
+	}
+
+	if err := checkGives(concreteType, abstractType, false); err != nil {
+		return nil, nil, err
+	}
+
+	return abstractType, &Binding{
+		bindingType:      "Abstract",
+		abstractType:     abstractType,
+		concreteType:     concreteType,
+		resolverFunction: concrete,
+		invocable:        invocable,
+	}, nil
+}
+
+// newAbstractFunctionBinding - Create the binding for an Abstract -> resolver function definition
+// The function must return the abstract, or something which implements it
+func newAbstractFunctionBinding(definition reflect.Type, resolverType reflect.Type, resolver any) (reflect.Type, *Binding, error) {
+	abstractType := getAbstractReturnType(definition)
+	if abstractType == nil {
+		return nil, nil, newError(ErrInvalidBinding, definition, "failed to get type of abstract", nil)
+	}
+
+	binding := &Binding{
+		bindingType: "Abstract",
+
+		resolverFunction:   resolver,
+		isFunctionResolver: true,
+
+		abstractType: abstractType,
+		concreteType: getConcreteReturnType(resolverType),
+
+		invocable: CreateInvocableFunction(resolver),
+	}
+
+	if err := checkBindingGives(binding, resolver, abstractType); err != nil {
+		return nil, nil, err
+	}
+
+	return abstractType, binding, nil
 }
 
 // addBinding - Convenience function to add a Binding for the type &
 // create a reverse lookup for Concrete -> Abstract
+// If the type was already bound, its Rebinding callbacks are called with the new instance
 func (container *ContainerInstance) addBinding(abstractType reflect.Type, binding *Binding) {
+	container.mu.Lock()
+	replaced := container.setBinding(abstractType, binding)
+	container.mu.Unlock()
+
+	if replaced != nil {
+		container.rebound(abstractType, replaced)
+	}
+}
+
+// setBinding - Does the work for addBinding, container.mu must be held by the caller
+// Returns the binding we replaced, its cached instance is evicted, or nil if the type wasn't bound
+// A conditional binding keeps the binding it was registered over as its fallback, so that isn't replaced
+func (container *ContainerInstance) setBinding(abstractType reflect.Type, binding *Binding) *Binding {
+	replaced := container.bindings[abstractType]
+	if replaced != nil && replaced == binding.fallback {
+		replaced = nil
+	}
+	if replaced != nil {
+		delete(container.resolved, replaced)
+		delete(container.failed, replaced)
+	}
+
+	binding.registeredType = abstractType
 	container.bindings[abstractType] = binding
 	container.concretes[binding.concreteType] = abstractType
+
+	return replaced
+}
+
+// addInstanceBinding - Create a singleton binding for an already instantiated value
+// The instance is stored straight into resolved, so it's never instantiated by the container
+// Any extenders for abstractType are applied to the instance first
+func (container *ContainerInstance) addInstanceBinding(abstractType reflect.Type, concreteType reflect.Type, instance any) error {
+	_, err := container.setInstanceBinding(abstractType, concreteType, instance, false)
+
+	return err
+}
+
+// setInstanceBinding - Does the work for addInstanceBinding & InstanceIf
+// When ifUnbound is true, nothing is registered if abstractType is already bound, returns whether we registered it
+func (container *ContainerInstance) setInstanceBinding(abstractType reflect.Type, concreteType reflect.Type, instance any, ifUnbound bool) (bool, error) {
+	if ifUnbound && container.IsBound(abstractType) {
+		return false, nil
+	}
+
+	binding := &Binding{
+		bindingType: "Singleton",
+
+		isFunctionResolver: false,
+		isSingleton:        true,
+		isInstance:         true,
+
+		abstractType:   abstractType,
+		concreteType:   concreteType,
+		registeredType: abstractType,
+		invocable:      CreateInvocable(concreteType),
+	}
+
+	instance, err := container.applyExtenders(binding, instance)
+	if err != nil {
+		return false, err
+	}
+
+	// Both are set under the same lock, so the instance can never be resolved
+	// from the binding before we've stored it
+	container.mu.Lock()
+	if ifUnbound && container.hasBinding(abstractType) {
+		container.mu.Unlock()
+		return false, nil
+	}
+
+	replaced := container.setBinding(abstractType, binding)
+
+	// Our instance is already instantiated, we'll pass it straight to resolved
+	container.resolved[binding] = instance
+	container.trackDisposable(instance, binding)
+	container.mu.Unlock()
+
+	container.attachToParent()
+
+	if replaced != nil {
+		container.rebound(abstractType, replaced)
+	}
+
+	return true, nil
+}
+
+// addBindingIf - The same as addBinding, but only if abstractType isn't bound in this container or our parents
+// Returns whether we registered it
+func (container *ContainerInstance) addBindingIf(abstractType reflect.Type, binding *Binding) bool {
+	if container.IsBound(abstractType) {
+		return false
+	}
+
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
+	if container.hasBinding(abstractType) {
+		return false
+	}
+
+	container.setBinding(abstractType, binding)
+
+	return true
+}
+
+// addConditionalBinding - The same as addBinding, but the binding is only used while its condition is true
+// Whatever abstractType was bound to in this container is kept, and used while it's false
+func (container *ContainerInstance) addConditionalBinding(abstractType reflect.Type, binding *Binding) {
+	container.mu.Lock()
+	binding.fallback = container.bindings[abstractType]
+	container.setBinding(abstractType, binding)
+	container.mu.Unlock()
 }
 
 func (container *ContainerInstance) addSingletonBinding(singletonType reflect.Type, binding *Binding) {
 	binding.isSingleton = true
 	container.addBinding(singletonType, binding)
 }
+
+func (container *ContainerInstance) addScopedBinding(scopedType reflect.Type, binding *Binding) {
+	binding.isScoped = true
+	container.addBinding(scopedType, binding)
+}
@@ -0,0 +1,132 @@
+package container
+
+// BindIf - The same as Bind, but only if the type isn't already bound, in this container or our parents
+// This allows libraries to register defaults, without overriding the application's own bindings,
+// no matter which is registered first
+//
+// For example:
+//  Container.BindIf((*Cache)(nil), NewMemoryCache)
+//
+// Returns true if the binding was registered, false if the type was already bound or the binding is invalid
+func (container *ContainerInstance) BindIf(bindingDef ...any) bool {
+	bound, err := container.BindIfE(bindingDef...)
+
+	return logError(err) && bound
+}
+
+// BindIfE - The same as BindIf, but returns an ErrInvalidBinding error when the binding can't be registered
+func (container *ContainerInstance) BindIfE(bindingDef ...any) (bool, error) {
+	bindingType, binding, err := createBinding(bindingDef)
+	if err != nil {
+		return false, err
+	}
+
+	return container.addBindingIf(bindingType, binding), nil
+}
+
+// SingletonIf - The same as Singleton, but only if the type isn't already bound, see BindIf
+func (container *ContainerInstance) SingletonIf(singleton any, concreteResolverFunc ...any) bool {
+	bound, err := container.SingletonIfE(singleton, concreteResolverFunc...)
+
+	return logError(err) && bound
+}
+
+// SingletonIfE - The same as SingletonIf, but returns an ErrInvalidBinding error when the singleton can't be registered
+func (container *ContainerInstance) SingletonIfE(singleton any, concreteResolverFunc ...any) (bool, error) {
+	singletonType, binding, err := createSharedBinding("Singleton", singleton, concreteResolverFunc)
+	if err != nil {
+		return false, err
+	}
+
+	binding.isSingleton = true
+
+	return container.addBindingIf(singletonType, binding), nil
+}
+
+// InstanceIf - The same as Instance, but only if the type isn't already bound, see BindIf
+func (container *ContainerInstance) InstanceIf(instance any) bool {
+	bound, err := container.InstanceIfE(instance)
+
+	return logError(err) && bound
+}
+
+// InstanceIfE - The same as InstanceIf, but returns an ErrInvalidBinding error when the instance can't be registered
+func (container *ContainerInstance) InstanceIfE(instance any) (bool, error) {
+	if instance == nil {
+		return false, newError(ErrInvalidBinding, nil, "InstanceIf() requires a non-nil instance", nil)
+	}
+
+	instanceType := getType(instance)
+
+	singletonConcrete := getConcreteReturnType(instanceType)
+	if singletonConcrete == nil {
+		return false, newError(ErrInvalidBinding, instanceType, "failed to get type of instance singleton", nil)
+	}
+
+	return container.setInstanceBinding(singletonConcrete, singletonConcrete, instance, true)
+}
+
+// BindWhen - The same as Bind, but only if predicate returns true, it's called once, when registering
+// Use BindWhenResolving to decide each time the type is resolved instead
+//
+// For example:
+//  Container.BindWhen(func() bool { return os.Getenv("CACHE_DRIVER") == "redis" }, (*Cache)(nil), NewRedisCache)
+//
+// Returns true if the binding was registered
+func (container *ContainerInstance) BindWhen(predicate func() bool, bindingDef ...any) bool {
+	bound, err := container.BindWhenE(predicate, bindingDef...)
+
+	return logError(err) && bound
+}
+
+// BindWhenE - The same as BindWhen, but returns an ErrInvalidBinding error when the binding can't be registered
+// The binding is validated even when predicate returns false
+func (container *ContainerInstance) BindWhenE(predicate func() bool, bindingDef ...any) (bool, error) {
+	if predicate == nil {
+		return false, newError(ErrInvalidBinding, nil, "BindWhen() requires a predicate", nil)
+	}
+
+	bindingType, binding, err := createBinding(bindingDef)
+	if err != nil {
+		return false, err
+	}
+
+	if !predicate() {
+		return false, nil
+	}
+
+	container.addBinding(bindingType, binding)
+
+	return true, nil
+}
+
+// BindWhenResolving - The same as Bind, but the binding is only used while predicate returns true
+// predicate is called each time the type is looked up, for example, to switch implementations with a profile
+// that can change while the app is running. While it returns false, whatever the type was bound to before
+// in this container is used, or our parent's binding, otherwise the type isn't bound.
+//
+// For example:
+//  Container.Bind((*Cache)(nil), NewMemoryCache)
+//  Container.BindWhenResolving(func() bool { return profile.Current() == "production" }, (*Cache)(nil), NewRedisCache)
+//
+// predicate is called while the container is locked, so it must not use the container
+func (container *ContainerInstance) BindWhenResolving(predicate func() bool, bindingDef ...any) bool {
+	return logError(container.BindWhenResolvingE(predicate, bindingDef...))
+}
+
+// BindWhenResolvingE - The same as BindWhenResolving, but returns an ErrInvalidBinding error when the binding can't be registered
+func (container *ContainerInstance) BindWhenResolvingE(predicate func() bool, bindingDef ...any) error {
+	if predicate == nil {
+		return newError(ErrInvalidBinding, nil, "BindWhenResolving() requires a predicate", nil)
+	}
+
+	bindingType, binding, err := createBinding(bindingDef)
+	if err != nil {
+		return err
+	}
+
+	binding.condition = predicate
+	container.addConditionalBinding(bindingType, binding)
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package container
+
+import (
+	"reflect"
+)
+
+// contextualBindingKey - Contextual bindings are keyed by the consumer & the abstract it needs
+type contextualBindingKey struct {
+	consumer reflect.Type
+	abstract reflect.Type
+}
+
+// ContextualBindingBuilder - Returned from When, use Needs & then Give to register the contextual binding
+type ContextualBindingBuilder struct {
+	container *ContainerInstance
+	consumers []reflect.Type
+	abstract  reflect.Type
+}
+
+// When - Start a contextual binding, so the consumers get a different implementation of an abstract
+// than everyone else. When one of the consumers is being built, its struct fields & function args use
+// the contextual binding, instead of the abstract's own binding.
+//
+// For example:
+//  Container.Bind(NewLocalStorage)
+//  Container.When(new(ReportService)).Needs((*Storage)(nil)).Give(NewS3Storage)
+//
+// ReportService gets the S3 Storage, everyone else gets the local one
+// Consumers are given in the same way as Make, a struct, a pointer to an interface, or the function that creates it
+func (container *ContainerInstance) When(consumers ...any) *ContextualBindingBuilder {
+	builder := &ContextualBindingBuilder{container: container}
+
+	for _, consumer := range consumers {
+		if consumer == nil {
+			continue
+		}
+		if consumerType := lookupType(getType(consumer)); consumerType != nil {
+			builder.consumers = append(builder.consumers, consumerType)
+		}
+	}
+
+	return builder
+}
+
+// Needs - The abstract the consumers depend on, which Give replaces
+func (builder *ContextualBindingBuilder) Needs(abstract any) *ContextualBindingBuilder {
+	if abstract != nil {
+		builder.abstract = lookupType(getType(abstract))
+	}
+
+	return builder
+}
+
+// Give - The concrete to give the consumers when they need the abstract
+// This accepts the same definitions as Bind with a single arg, a function which returns the
+// implementation(its args are resolved from the container), or a struct type to instantiate & fill
+func (builder *ContextualBindingBuilder) Give(concrete any) bool {
+	return logError(builder.GiveE(concrete))
+}
+
+// GiveE - The same as Give, but returns an ErrInvalidBinding error when the binding can't be registered
+func (builder *ContextualBindingBuilder) GiveE(concrete any) error {
+	if len(builder.consumers) == 0 {
+		return newError(ErrInvalidBinding, nil, "When() requires at-least one consumer", nil)
+	}
+	if builder.abstract == nil {
+		return newError(ErrInvalidBinding, nil, "Needs() must be called with the abstract before Give()", nil)
+	}
+
+	_, binding, err := createBinding([]any{concrete})
+	if err != nil {
+		return err
+	}
+
+	if err := checkBindingGives(binding, concrete, builder.abstract); err != nil {
+		return err
+	}
+
+	binding.registeredType = builder.abstract
+
+	container := builder.container
+
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
+	for _, consumer := range builder.consumers {
+		container.contextual[contextualBindingKey{consumer: consumer, abstract: builder.abstract}] = binding
+	}
+
+	return nil
+}
+
+// findContextualBinding - Look up a contextual binding of typ, for any of the consumers
+// in this container, then our parents. Consumers are checked in order, so the first one wins
+// Returns the binding, the type it was bound under & the container it was bound to
+func (container *ContainerInstance) findContextualBinding(consumers []reflect.Type, typ reflect.Type) (*Binding, reflect.Type, *ContainerInstance) {
+	abstract := lookupType(typ)
+	if abstract == nil {
+		return nil, nil, nil
+	}
+
+	for c := container; c != nil; c = c.ParentContainer() {
+		if binding := c.getOwnContextualBinding(consumers, abstract); binding != nil {
+			return binding, abstract, c
+		}
+	}
+
+	return nil, nil, nil
+}
+
+func (container *ContainerInstance) getOwnContextualBinding(consumers []reflect.Type, abstract reflect.Type) *Binding {
+	container.mu.RLock()
+	defer container.mu.RUnlock()
+
+	if len(container.contextual) == 0 {
+		return nil
+	}
+
+	for _, consumer := range consumers {
+		if consumer == nil {
+			continue
+		}
+		if binding, ok := container.contextual[contextualBindingKey{consumer: consumer, abstract: abstract}]; ok {
+			return binding
+		}
+	}
+
+	return nil
+}
+
+// resolveDependency - Resolve typ, which one of the consumers depends on
+// A contextual binding for the consumer wins over typ's own binding
+// Returns false, with a nil error when typ isn't bound
+func (container *ContainerInstance) resolveDependency(res *resolution, site resolutionSite, consumers []reflect.Type, typ reflect.Type) (any, bool, error) {
+	if binding, boundType, owner := container.findContextualBinding(consumers, typ); binding != nil {
+		resolved, err := container.makeFromFoundBinding(res, site.step(boundType, binding), binding, owner)
+		return resolved, true, err
+	}
+
+	bindingType := container.getBindingType(typ)
+	if bindingType == nil {
+		// A []abstract which isn't bound itself, gets the implementations added with BindMany/Append
+		return container.resolveMany(res, site, typ)
+	}
+
+	resolved, err := container.makeFromBinding(res, site, bindingType)
+
+	return resolved, true, err
+}
+
+// makeDependency - The same as resolveDependency, for resolving outside of a Make/Call, for example from a Lazy
+// When typ isn't bound, an ErrNotBound error is returned
+func (container *ContainerInstance) makeDependency(consumers []reflect.Type, typ reflect.Type) (any, error) {
+	resolved, ok, err := container.resolveDependency(newResolution(), topLevelSite, consumers, typ)
+	if !ok {
+		return nil, newError(ErrNotBound, lookupType(typ), "", nil)
+	}
+
+	return resolved, err
+}
+
+// isDependencyBound - Check if resolveDependency can resolve typ for the consumers
+func (container *ContainerInstance) isDependencyBound(consumers []reflect.Type, typ reflect.Type) bool {
+	if binding, _, _ := container.findContextualBinding(consumers, typ); binding != nil {
+		return true
+	}
+
+	return container.getBindingType(typ) != nil || container.isManyBound(typ)
+}
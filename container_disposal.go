@@ -0,0 +1,186 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// Disposable - Implement this on a service to have it cleaned up when the container that created it is closed
+// If a service implements both Disposable & io.Closer, only Dispose is called
+type Disposable interface {
+	Dispose(ctx context.Context) error
+}
+
+// trackedDisposable - An instance which Close should dispose of
+type trackedDisposable struct {
+	instance any
+	// The binding of an instance bound with Instance(), nil when the instance was created by the container
+	instanceBinding *Binding
+}
+
+// CloseError - Returned from Close, it holds every error returned while disposing instances
+type CloseError struct {
+	Errors []error
+}
+
+func (e *CloseError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+
+	return "container: failed to close " + strings.Join(messages, "; ")
+}
+
+// Is - Allows errors.Is to match any of the errors returned while closing
+func (e *CloseError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As - Allows errors.As to match any of the errors returned while closing
+func (e *CloseError) As(target any) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// trackDisposable - Remember the instance, if it needs to be disposed when we're closed
+// instanceBinding is the binding of a value bound with Instance(), or nil
+// container.mu must be held by the caller
+func (container *ContainerInstance) trackDisposable(instance any, instanceBinding *Binding) {
+	switch instance.(type) {
+	case Disposable, io.Closer:
+		container.disposables = append(container.disposables, trackedDisposable{
+			instance:        instance,
+			instanceBinding: instanceBinding,
+		})
+	}
+}
+
+// Close - Dispose of every singleton & scoped instance this container created, which implements
+// Disposable or io.Closer. Transient instances are only disposed when Config.DisposeTransients is true,
+// values bound via Instance() are only disposed when Config.DisposeInstances is true.
+//
+// Any child containers are closed first, then our instances are disposed in the reverse order
+// they were created, so an instance is disposed before the dependencies it was created with.
+// Every instance is disposed even if some fail, the errors are returned together in a CloseError.
+// If ctx is cancelled, we'll stop disposing and return ctx.Err() with the other errors.
+//
+// Once closed, our resolved singleton/scoped instances are cleared, the container can still be used,
+// but they'll be created again when they're next resolved. Values bound via Instance() can't be created
+// again, so they're kept, unless they were disposed, then their binding is removed like ForgetInstance.
+// The ones we didn't dispose are still tracked, so closing again with Config.DisposeInstances disposes them
+func (container *ContainerInstance) Close(ctx context.Context) error {
+	var errs []error
+
+	container.mu.Lock()
+	children := container.children
+	container.children = nil
+	container.mu.Unlock()
+
+	for i := len(children) - 1; i >= 0; i-- {
+		if err := children[i].Close(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	container.mu.Lock()
+	disposables := container.disposables
+	container.disposables = nil
+	for binding := range container.resolved {
+		if !binding.isInstance {
+			delete(container.resolved, binding)
+		}
+	}
+	for k := range container.failed {
+		delete(container.failed, k)
+	}
+	container.mu.Unlock()
+
+	// Instance() values we don't dispose are still bound, so they're still tracked, a later Close can dispose them
+	var kept []trackedDisposable
+	for _, disposable := range disposables {
+		if disposable.instanceBinding != nil && !container.Config.DisposeInstances {
+			kept = append(kept, disposable)
+		}
+	}
+	if len(kept) > 0 {
+		container.mu.Lock()
+		container.disposables = append(kept, container.disposables...)
+		container.mu.Unlock()
+	}
+
+	for i := len(disposables) - 1; i >= 0; i-- {
+		disposable := disposables[i]
+		if disposable.instanceBinding != nil && !container.Config.DisposeInstances {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		if err := dispose(ctx, disposable.instance); err != nil {
+			errs = append(errs, newError(ErrDisposeFailed, reflect.TypeOf(disposable.instance), "", err))
+		}
+
+		// A disposed instance can't be used again, and we can't create another
+		if binding := disposable.instanceBinding; binding != nil {
+			container.mu.Lock()
+			delete(container.resolved, binding)
+			container.mu.Unlock()
+
+			container.forgetInstanceBinding(binding.registeredType, binding)
+		}
+	}
+
+	container.detachFromParent()
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return &CloseError{Errors: errs}
+}
+
+func dispose(ctx context.Context, instance any) error {
+	switch disposable := instance.(type) {
+	case Disposable:
+		return disposable.Dispose(ctx)
+	case io.Closer:
+		return disposable.Close()
+	}
+
+	return nil
+}
+
+// removeChild - Stop tracking a child container, once it's been closed or reset
+func (container *ContainerInstance) removeChild(child *ContainerInstance) {
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
+	for i, c := range container.children {
+		if c == child {
+			// Clear the last slot, so the backing array doesn't keep the child alive
+			last := len(container.children) - 1
+			copy(container.children[i:], container.children[i+1:])
+			container.children[last] = nil
+			container.children = container.children[:last]
+			return
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package container
+
+import (
+	"reflect"
+)
+
+var containerInstanceType = reflect.TypeOf((*ContainerInstance)(nil))
+
+// Extend - Decorate the instances resolved for an abstract, without replacing its binding
+// This allows us to wrap services that were registered somewhere else, for example logging around a Mailer
+//
+// The extender is a function which receives the resolved instance & the container resolving it,
+// whatever it returns is used instead:
+//  Container.Extend((*Mailer)(nil), func(mailer Mailer, c *container.ContainerInstance) Mailer {
+//  	return &LoggingMailer{Mailer: mailer}
+//  })
+//
+// Extenders are applied in the order they were registered, for every binding of the abstract,
+// function, concrete, abstract & singleton(the decorated singleton is what's cached).
+// Extending a singleton which is already resolved applies the extender to it straight away.
+// Extenders registered on a parent container are also applied to resolutions in its children.
+func (container *ContainerInstance) Extend(abstract any, extender any) bool {
+	return logError(container.ExtendE(abstract, extender))
+}
+
+// ExtendE - The same as Extend, but returns an ErrInvalidBinding error when the extender can't be registered
+func (container *ContainerInstance) ExtendE(abstract any, extender any) error {
+	if abstract == nil {
+		return newError(ErrInvalidBinding, nil, "Extend() requires an abstract", nil)
+	}
+
+	abstractType := lookupType(getType(abstract))
+	if abstractType == nil {
+		return newError(ErrInvalidBinding, getType(abstract), "failed to get type of abstract", nil)
+	}
+
+	extenderValue, err := validateExtender(abstractType, extender)
+	if err != nil {
+		return err
+	}
+
+	container.mu.Lock()
+	container.extenders[abstractType] = append(container.extenders[abstractType], extenderValue)
+	container.mu.Unlock()
+
+	return container.extendResolved(abstractType, extenderValue)
+}
+
+// validateExtender - Check extender is a func(T, *ContainerInstance) T, where T is what abstractType resolves to
+func validateExtender(abstractType reflect.Type, extender any) (reflect.Value, error) {
+	if extender == nil {
+		return reflect.Value{}, newError(ErrInvalidBinding, abstractType, "Extend() requires an extender function", nil)
+	}
+
+	extenderType := getType(extender)
+
+	if extenderType.Kind() != reflect.Func ||
+		extenderType.NumIn() != 2 ||
+		extenderType.NumOut() != 1 ||
+		extenderType.In(1) != containerInstanceType ||
+		extenderType.In(0) != extenderType.Out(0) {
+		return reflect.Value{}, newError(
+			ErrInvalidBinding,
+			extenderType,
+			"extender must be a func(T, *ContainerInstance) T",
+			nil,
+		)
+	}
+
+	if instanceType := extenderType.In(0); instanceType != abstractType && pointerElemType(instanceType) != abstractType {
+		return reflect.Value{}, newError(
+			ErrInvalidBinding,
+			extenderType,
+			"extender can't extend "+abstractType.String(),
+			nil,
+		)
+	}
+
+	return getVal(extender), nil
+}
+
+// extendResolved - Apply a new extender to the instances of abstractType we've already resolved
+// This includes our singletons & the scoped instances cached in our children
+func (container *ContainerInstance) extendResolved(abstractType reflect.Type, extender reflect.Value) error {
+	container.mu.RLock()
+	resolved := map[*Binding]any{}
+	for binding, instance := range container.resolved {
+		if binding.registeredType == abstractType {
+			resolved[binding] = instance
+		}
+	}
+	children := append([]*ContainerInstance{}, container.children...)
+	container.mu.RUnlock()
+
+	// Extenders can resolve from the container, so we don't hold the lock while calling them
+	for binding, instance := range resolved {
+		extended, err := container.callExtender(extender, instance)
+		if err != nil {
+			return err
+		}
+
+		container.mu.Lock()
+		if current, ok := container.resolved[binding]; ok && current == instance {
+			container.resolved[binding] = extended
+		}
+		container.mu.Unlock()
+	}
+
+	for _, child := range children {
+		if err := child.extendResolved(abstractType, extender); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyExtenders - Apply the extenders registered for the binding's type, from this container & our parents
+// Our root container's extenders are applied first, then each child's, in the order they were registered
+func (container *ContainerInstance) applyExtenders(binding *Binding, instance any) (any, error) {
+	if binding.registeredType == nil || instance == nil {
+		return instance, nil
+	}
+
+	var extenders []reflect.Value
+	for c := container; c != nil; c = c.ParentContainer() {
+		c.mu.RLock()
+		extenders = append(append([]reflect.Value{}, c.extenders[binding.registeredType]...), extenders...)
+		c.mu.RUnlock()
+	}
+
+	var err error
+	for _, extender := range extenders {
+		instance, err = container.callExtender(extender, instance)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return instance, nil
+}
+
+// callExtender - Call the extender with the instance & this container, returns what it returned
+func (container *ContainerInstance) callExtender(extender reflect.Value, instance any) (any, error) {
+	instanceValue, err := assignableValue(instance, extender.Type().In(0))
+	if err != nil {
+		return nil, err
+	}
+
+	return extender.Call([]reflect.Value{instanceValue, reflect.ValueOf(container)})[0].Interface(), nil
+}
@@ -0,0 +1,104 @@
+package container
+
+import (
+	"reflect"
+)
+
+// Forget - Remove the binding registered for abstract from this container, along with its reverse lookup in
+// concretes & its tag memberships. Any instances of it we've cached are dropped, including the scoped
+// instances cached in our children, but our parent containers are never touched. Only abstract's own
+// binding is removed, forgetting a concrete type doesn't remove the abstract it's bound to
+//
+// For example:
+//  Container.Forget((*Cache)(nil))
+//
+// Returns true if abstract was bound in this container
+func (container *ContainerInstance) Forget(abstract any) bool {
+	if abstract == nil {
+		return false
+	}
+
+	// Only the binding registered for abstract itself, a concrete's reverse lookup would find the abstract it's bound to
+	bindingType := lookupType(getType(abstract))
+	if bindingType == nil {
+		return false
+	}
+
+	container.mu.Lock()
+	binding, ok := container.bindings[bindingType]
+	if !ok {
+		container.mu.Unlock()
+		return false
+	}
+
+	delete(container.bindings, bindingType)
+
+	for concreteType, abstractType := range container.concretes {
+		if abstractType == bindingType {
+			delete(container.concretes, concreteType)
+		}
+	}
+
+	for tag, taggedTypes := range container.tagged {
+		container.tagged[tag] = removeType(taggedTypes, bindingType)
+	}
+
+	container.mu.Unlock()
+
+	// A conditional binding also holds the bindings it was registered over
+	for b := binding; b != nil; b = b.fallback {
+		container.evict(b)
+	}
+
+	return true
+}
+
+// ForgetInstance - Drop the cached instance of abstract from this container, so it's created again
+// the next time it's resolved. This is the singleton bound to this container, or a scoped instance
+// we cached for a binding in our parent, the parent's own instance is kept
+//
+// A value bound with Instance() can't be created again, so its binding is forgotten too
+//
+// Returns true if we had an instance of abstract cached
+func (container *ContainerInstance) ForgetInstance(abstract any) bool {
+	if abstract == nil {
+		return false
+	}
+
+	bindingType := container.getBindingType(getType(abstract))
+	if bindingType == nil {
+		return false
+	}
+
+	binding, _ := container.findBinding(bindingType)
+	if binding == nil {
+		return false
+	}
+
+	container.mu.Lock()
+	_, ok := container.resolved[binding]
+	delete(container.resolved, binding)
+	delete(container.failed, binding)
+	container.mu.Unlock()
+
+	if ok && binding.isInstance {
+		container.forgetInstanceBinding(bindingType, binding)
+	}
+
+	return ok
+}
+
+// forgetInstanceBinding - Remove an Instance() binding, if it's still bound in this container
+func (container *ContainerInstance) forgetInstanceBinding(bindingType reflect.Type, binding *Binding) {
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
+	if container.bindings[bindingType] != binding {
+		return
+	}
+
+	delete(container.bindings, bindingType)
+	if container.concretes[binding.concreteType] == bindingType {
+		delete(container.concretes, binding.concreteType)
+	}
+}
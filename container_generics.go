@@ -0,0 +1,222 @@
+package container
+
+import (
+	"reflect"
+)
+
+// The functions in this file are a type safe layer on top of ContainerInstance
+// Go doesn't allow type params on methods, so they take the container as their first arg
+// They all use the same bindings as Bind/Make etc., so both apis can be mixed freely
+
+// typeOf - Get the reflect.Type of T, this also works when T is an interface
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// Resolve - Type safe version of MakeE
+// For example:
+//  service, err := container.Resolve[ServiceAbstract](Container)
+func Resolve[T any](container *ContainerInstance, parameters ...any) (T, error) {
+	resolved, err := container.MakeE(typeOf[T](), parameters...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return castTo[T](resolved)
+}
+
+// ResolveNamed - Type safe version of MakeNamedE
+// For example:
+//  replica, err := container.ResolveNamed[Database](Container, "replica")
+func ResolveNamed[T any](container *ContainerInstance, name string, parameters ...any) (T, error) {
+	resolved, err := container.MakeNamedE(name, typeOf[T](), parameters...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return castTo[T](resolved)
+}
+
+// MustResolve - The same as Resolve, but panics if T can't be resolved
+// Useful when booting an app, where a missing binding is a programming error
+func MustResolve[T any](container *ContainerInstance, parameters ...any) T {
+	resolved, err := Resolve[T](container, parameters...)
+	if err != nil {
+		panic(err)
+	}
+
+	return resolved
+}
+
+// BindTo - Bind the abstract I to the concrete C, C is instantiated & has its fields filled when I is resolved
+// Optionally, a resolver function that returns C can be passed, it's used in the same way as
+// ContainerInstance.Bind((*I)(nil), resolver)
+//
+// Go can't express "C implements I" as a constraint between two type params, so this is checked
+// as soon as BindTo is called, rather than when I is first resolved. If C or *C doesn't implement I,
+// nothing is bound and an ErrInvalidBinding error is returned.
+//
+// For example:
+//  err := container.BindTo[ServiceAbstract, ServiceConcrete](Container)
+//  err := container.BindTo[ServiceAbstract, *ServiceConcrete](Container, NewServiceConcrete)
+func BindTo[I any, C any](container *ContainerInstance, resolver ...any) error {
+	abstractType := typeOf[I]()
+	concreteType := typeOf[C]()
+
+	if abstractType.Kind() != reflect.Interface {
+		return newError(ErrInvalidBinding, abstractType, "BindTo() requires an interface as the abstract", nil)
+	}
+
+	if err := checkGives(concreteType, abstractType, false); err != nil {
+		return err
+	}
+
+	if len(resolver) > 0 {
+		return container.BindE(abstractType, resolver[0])
+	}
+
+	return container.BindE(abstractType, concreteType)
+}
+
+// SingletonOf - Type safe version of SingletonE
+// For example:
+//  err := container.SingletonOf[*DatabaseService](Container)
+//  err := container.SingletonOf[DatabaseAbstract](Container, NewDatabaseService)
+func SingletonOf[T any](container *ContainerInstance, resolver ...any) error {
+	return container.SingletonE(typeOf[T](), resolver...)
+}
+
+// ScopedOf - Type safe version of ScopedE
+func ScopedOf[T any](container *ContainerInstance, resolver ...any) error {
+	return container.ScopedE(typeOf[T](), resolver...)
+}
+
+// InstanceOf - Type safe version of InstanceE
+// Unlike InstanceE, the instance is bound under T, so an instance can be bound to an interface
+// For example:
+//  err := container.InstanceOf[ServiceAbstract](Container, &ServiceConcrete{})
+func InstanceOf[T any](container *ContainerInstance, instance T) error {
+	instanceValue := reflect.ValueOf(instance)
+	if !instanceValue.IsValid() {
+		return newError(ErrInvalidBinding, typeOf[T](), "InstanceOf() requires a non-nil instance", nil)
+	}
+
+	abstractType := getConcreteReturnType(typeOf[T]())
+	concreteType := getConcreteReturnType(instanceValue.Type())
+
+	return container.addInstanceBinding(abstractType, concreteType, instance)
+}
+
+// ExtendOf - Type safe version of ExtendE
+// For example:
+//  err := container.ExtendOf[Mailer](Container, func(mailer Mailer, c *container.ContainerInstance) Mailer {
+//  	return &LoggingMailer{Mailer: mailer}
+//  })
+func ExtendOf[T any](container *ContainerInstance, extender func(T, *ContainerInstance) T) error {
+	return container.ExtendE(typeOf[T](), extender)
+}
+
+// ResolvingOf - Type safe version of ResolvingE
+func ResolvingOf[T any](container *ContainerInstance, callback func(T, *ContainerInstance)) error {
+	return container.ResolvingE(typeOf[T](), callback)
+}
+
+// AfterResolvingOf - Type safe version of AfterResolvingE
+func AfterResolvingOf[T any](container *ContainerInstance, callback func(T, *ContainerInstance)) error {
+	return container.AfterResolvingE(typeOf[T](), callback)
+}
+
+// RebindingOf - Type safe version of RebindingE
+func RebindingOf[T any](container *ContainerInstance, callback func(*ContainerInstance, T)) error {
+	return container.RebindingE(typeOf[T](), callback)
+}
+
+// TaggedOf - Type safe version of TaggedE
+// If any of the tagged instances aren't a T, an ErrInvalidTarget error is returned
+func TaggedOf[T any](container *ContainerInstance, tag string) ([]T, error) {
+	tagged, err := container.TaggedE(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make([]T, len(tagged))
+	for i, instance := range tagged {
+		resolved[i], err = castTo[T](instance)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resolved, nil
+}
+
+// Fake - Type safe version of Swap, fake replaces the binding for T until restore is called
+// For example:
+//  t.Cleanup(container.Fake[Mailer](Container, &FakeMailer{}))
+func Fake[T any](container *ContainerInstance, fake T) (restore func()) {
+	return container.Swap(typeOf[T](), fake)
+}
+
+// InjectTagged - Create a TaggedArg, which injects the bindings tagged with tag into a function's []T arg
+// For example:
+//  Container.Bind(NewDashboard, container.InjectTagged[StatService]("StatServices"))
+func InjectTagged[T any](tag string) TaggedArg {
+	return TaggedArg{tag: tag, sliceType: reflect.SliceOf(typeOf[T]())}
+}
+
+// CallTyped - Type safe version of CallE, the first return value of function is returned as R
+// If function returns (R, error), a non-nil error is returned as is
+// For example:
+//  message, err := container.CallTyped[string](Container, func(service ServiceAbstract) string {
+//  	return service.Message()
+//  })
+func CallTyped[R any](container *ContainerInstance, function any, parameters ...any) (R, error) {
+	var zero R
+
+	returnValues, err := container.CallE(function, parameters...)
+	if err != nil {
+		return zero, err
+	}
+
+	if len(returnValues) == 0 {
+		return zero, newError(ErrInvalidTarget, typeOf[R](), "called function has no return values", nil)
+	}
+
+	if len(returnValues) >= 2 {
+		if err, ok := returnValues[len(returnValues)-1].(error); ok {
+			return zero, err
+		}
+	}
+
+	return castTo[R](returnValues[0])
+}
+
+// castTo - Convert a resolved value to T
+// Concrete bindings are instantiated as a pointer, so we'll also de-reference them when T isn't a pointer
+func castTo[T any](resolved any) (T, error) {
+	var zero T
+
+	if resolved == nil {
+		return zero, nil
+	}
+
+	if value, ok := resolved.(T); ok {
+		return value, nil
+	}
+
+	resolvedValue := reflect.ValueOf(resolved)
+	if resolvedValue.Kind() == reflect.Ptr && !resolvedValue.IsNil() {
+		if value, ok := resolvedValue.Elem().Interface().(T); ok {
+			return value, nil
+		}
+	}
+
+	return zero, newError(
+		ErrInvalidTarget,
+		typeOf[T](),
+		"resolved value of type "+resolvedValue.Type().String()+" is not assignable",
+		nil,
+	)
+}
@@ -1,5 +1,7 @@
 package container
 
+import "context"
+
 // Not sure if this is the right way to do it...
 // We're exposing some function which are the same as using "Container"
 // but for an end user, they will have to use "container.Container"
@@ -8,24 +10,150 @@ package container
 func Bind(bindingDef ...any) bool {
 	return Container.Bind(bindingDef...)
 }
+func BindE(bindingDef ...any) error {
+	return Container.BindE(bindingDef...)
+}
+func BindNamed(name string, bindingDef ...any) bool {
+	return Container.BindNamed(name, bindingDef...)
+}
+func BindNamedE(name string, bindingDef ...any) error {
+	return Container.BindNamedE(name, bindingDef...)
+}
 func Singleton(singleton any, concreteResolverFunc ...any) bool {
 	return Container.Singleton(singleton, concreteResolverFunc...)
 }
+func SingletonE(singleton any, concreteResolverFunc ...any) error {
+	return Container.SingletonE(singleton, concreteResolverFunc...)
+}
+func SingletonNamed(name string, singleton any, concreteResolverFunc ...any) bool {
+	return Container.SingletonNamed(name, singleton, concreteResolverFunc...)
+}
+func SingletonNamedE(name string, singleton any, concreteResolverFunc ...any) error {
+	return Container.SingletonNamedE(name, singleton, concreteResolverFunc...)
+}
+func Scoped(scoped any, concreteResolverFunc ...any) bool {
+	return Container.Scoped(scoped, concreteResolverFunc...)
+}
+func ScopedE(scoped any, concreteResolverFunc ...any) error {
+	return Container.ScopedE(scoped, concreteResolverFunc...)
+}
 func Instance(instance any) bool {
 	return Container.Instance(instance)
 }
+func InstanceE(instance any) error {
+	return Container.InstanceE(instance)
+}
+func BindIf(bindingDef ...any) bool {
+	return Container.BindIf(bindingDef...)
+}
+func BindIfE(bindingDef ...any) (bool, error) {
+	return Container.BindIfE(bindingDef...)
+}
+func SingletonIf(singleton any, concreteResolverFunc ...any) bool {
+	return Container.SingletonIf(singleton, concreteResolverFunc...)
+}
+func SingletonIfE(singleton any, concreteResolverFunc ...any) (bool, error) {
+	return Container.SingletonIfE(singleton, concreteResolverFunc...)
+}
+func InstanceIf(instance any) bool {
+	return Container.InstanceIf(instance)
+}
+func InstanceIfE(instance any) (bool, error) {
+	return Container.InstanceIfE(instance)
+}
+func BindWhen(predicate func() bool, bindingDef ...any) bool {
+	return Container.BindWhen(predicate, bindingDef...)
+}
+func BindWhenE(predicate func() bool, bindingDef ...any) (bool, error) {
+	return Container.BindWhenE(predicate, bindingDef...)
+}
+func BindWhenResolving(predicate func() bool, bindingDef ...any) bool {
+	return Container.BindWhenResolving(predicate, bindingDef...)
+}
+func BindWhenResolvingE(predicate func() bool, bindingDef ...any) error {
+	return Container.BindWhenResolvingE(predicate, bindingDef...)
+}
+func BindMany(abstract any, concretes ...any) bool {
+	return Container.BindMany(abstract, concretes...)
+}
+func BindManyE(abstract any, concretes ...any) error {
+	return Container.BindManyE(abstract, concretes...)
+}
+func Append(abstract any, concrete any) bool {
+	return Container.Append(abstract, concrete)
+}
+func AppendE(abstract any, concrete any) error {
+	return Container.AppendE(abstract, concrete)
+}
+func Extend(abstract any, extender any) bool {
+	return Container.Extend(abstract, extender)
+}
+func ExtendE(abstract any, extender any) error {
+	return Container.ExtendE(abstract, extender)
+}
+func Resolving(abstract any, callback any) bool {
+	return Container.Resolving(abstract, callback)
+}
+func ResolvingE(abstract any, callback any) error {
+	return Container.ResolvingE(abstract, callback)
+}
+func AfterResolving(abstract any, callback any) bool {
+	return Container.AfterResolving(abstract, callback)
+}
+func AfterResolvingE(abstract any, callback any) error {
+	return Container.AfterResolvingE(abstract, callback)
+}
+func ResolvingAny(callback func(instance any, container *ContainerInstance)) {
+	Container.ResolvingAny(callback)
+}
+func AfterResolvingAny(callback func(instance any, container *ContainerInstance)) {
+	Container.AfterResolvingAny(callback)
+}
+func Rebinding(abstract any, callback any) bool {
+	return Container.Rebinding(abstract, callback)
+}
+func RebindingE(abstract any, callback any) error {
+	return Container.RebindingE(abstract, callback)
+}
+func Refresh(abstract any, target any, method string) any {
+	return Container.Refresh(abstract, target, method)
+}
+func RefreshE(abstract any, target any, method string) (any, error) {
+	return Container.RefreshE(abstract, target, method)
+}
 func IsBound(binding any) bool {
 	return Container.IsBound(binding)
 }
+func IsBoundNamed(name string, binding any) bool {
+	return Container.IsBoundNamed(name, binding)
+}
 func Make(abstract any, parameters ...any) any {
 	return Container.Make(abstract, parameters...)
 }
+func MakeE(abstract any, parameters ...any) (any, error) {
+	return Container.MakeE(abstract, parameters...)
+}
+func MakeNamed(name string, abstract any, parameters ...any) any {
+	return Container.MakeNamed(name, abstract, parameters...)
+}
+func MakeNamedE(name string, abstract any, parameters ...any) (any, error) {
+	return Container.MakeNamedE(name, abstract, parameters...)
+}
 func MakeTo(makeTo any, parameters ...any) {
 	Container.MakeTo(makeTo, parameters...)
 }
+func MakeToE(makeTo any, parameters ...any) error {
+	return Container.MakeToE(makeTo, parameters...)
+}
+func When(consumers ...any) *ContextualBindingBuilder {
+	return Container.When(consumers...)
+}
 func CreateChildContainer() *ContainerInstance {
 	return Container.CreateChildContainer()
 }
+func Close(ctx context.Context) error {
+	return Container.Close(ctx)
+}
 func ClearInstances() {
 	Container.ClearInstances()
 }
@@ -38,9 +166,33 @@ func ParentContainer() *ContainerInstance {
 func Call(function any, parameters ...any) []any {
 	return Container.Call(function, parameters...)
 }
+func CallE(function any, parameters ...any) ([]any, error) {
+	return Container.CallE(function, parameters...)
+}
 func Tag(tag string, bindings ...any) bool {
 	return Container.Tag(tag, bindings...)
 }
 func Tagged(tag string) []any {
 	return Container.Tagged(tag)
 }
+func TaggedE(tag string) ([]any, error) {
+	return Container.TaggedE(tag)
+}
+func Swap(abstract any, fake any) (restore func()) {
+	return Container.Swap(abstract, fake)
+}
+func SwapE(abstract any, fake any) (func(), error) {
+	return Container.SwapE(abstract, fake)
+}
+func Forget(abstract any) bool {
+	return Container.Forget(abstract)
+}
+func ForgetInstance(abstract any) bool {
+	return Container.ForgetInstance(abstract)
+}
+func Untag(tag string, bindings ...any) bool {
+	return Container.Untag(tag, bindings...)
+}
+func Validate() error {
+	return Container.Validate()
+}
@@ -1,16 +1,17 @@
 package container
 
 import (
-	"log"
 	"reflect"
 	"unsafe"
 )
 
 // hasBinding - Look up a Type in the container and return whether it exists
+// A conditional binding only exists while its condition is true, or it has a fallback
+// container.mu must be held by the caller
 func (container *ContainerInstance) hasBinding(binding reflect.Type) bool {
-	_, ok := container.bindings[binding]
+	containerBinding, ok := container.bindings[binding]
 
-	return ok
+	return ok && containerBinding.active() != nil
 }
 
 // getBindingType - Try to get a binding type from the binding arg in a few different ways
@@ -21,6 +22,25 @@ func (container *ContainerInstance) hasBinding(binding reflect.Type) bool {
 func (container *ContainerInstance) getBindingType(binding any) reflect.Type {
 	bindingType := getType(binding)
 
+	if t := container.getOwnBindingType(bindingType); t != nil {
+		return t
+	}
+
+	// ... and one more final last ditch effort... check call this method on the parent container
+	if parent := container.ParentContainer(); parent != nil {
+		if t := parent.getBindingType(bindingType); t != nil {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// getOwnBindingType - The lookups from getBindingType, but only for this container's bindings
+func (container *ContainerInstance) getOwnBindingType(bindingType reflect.Type) reflect.Type {
+	container.mu.RLock()
+	defer container.mu.RUnlock()
+
 	// First, we'll check if we have this type as a singleton binding
 	testType := getConcreteReturnType(bindingType)
 	if container.hasBinding(testType) {
@@ -46,31 +66,119 @@ func (container *ContainerInstance) getBindingType(binding any) reflect.Type {
 		}
 	}
 
-	// ... and one more final last ditch effort... check call this method on the parent container
-	if container.parent != nil {
-		if t := container.parent.getBindingType(bindingType); t != nil {
-			return t
+	return nil
+}
+
+// findBinding - Look up the Binding registered for a type in this container, then our parents
+// Returns the binding and the container it was bound to, or nil if it's not bound anywhere
+func (container *ContainerInstance) findBinding(binding reflect.Type) (*Binding, *ContainerInstance) {
+	for c := container; c != nil; c = c.ParentContainer() {
+		if containerBinding, ok := c.getBinding(binding); ok {
+			return containerBinding, c
 		}
 	}
 
-	return nil
+	return nil, nil
 }
 
-// makeFromBinding - Once we've obtained our binding type from
-// Make, we'll then check the containers bindings
-// If it doesn't exist, and we have a parent container we'll then call makeFromBinding on the
-// parent container. Which will either recurse until a resolve is made, or return nil
-func (container *ContainerInstance) makeFromBinding(binding reflect.Type, parameters ...any) any {
+// getBinding - Look up the Binding registered for a type in this container only
+func (container *ContainerInstance) getBinding(binding reflect.Type) (*Binding, bool) {
+	container.mu.RLock()
+	defer container.mu.RUnlock()
+
 	containerBinding, ok := container.bindings[binding]
 	if !ok {
-		if container.parent != nil {
-			return container.parent.makeFromBinding(binding, parameters...)
-		}
-		log.Printf("Failed to resolve container binding for abstract type %s", binding.String())
+		return nil, false
+	}
+
+	containerBinding = containerBinding.active()
+
+	return containerBinding, containerBinding != nil
+}
+
+// makeFromBinding - Once we've obtained our binding type from
+// Make, we'll then check the containers bindings
+// If it doesn't exist, we'll look it up in our parent containers, if we still can't find it
+// we'll return ErrNotBound
+//
+// res tracks the bindings being resolved, if the binding is already being resolved further up
+// the chain, we'll return ErrCircularDependency rather than recursing forever
+// site is where the binding is required from(function arg/struct field), any failure is
+// returned as a ResolutionError which holds the full path down to the failed binding
+//
+// Singletons are resolved & cached by the container they were bound to, so they only depend on that
+// container's bindings. Everything else is resolved by this container, so bindings in a child container
+// override the parents for the dependencies, and scoped bindings are cached in the child
+func (container *ContainerInstance) makeFromBinding(res *resolution, site resolutionSite, binding reflect.Type, parameters ...any) (any, error) {
+	containerBinding, owner := container.findBinding(binding)
+	if containerBinding == nil {
+		step := site.step(binding, nil)
+		return nil, res.fail(&step, newError(ErrNotBound, binding, "failed to resolve container binding", nil))
+	}
+
+	return container.makeFromFoundBinding(res, site.step(binding, containerBinding), containerBinding, owner, parameters...)
+}
+
+// makeFromFoundBinding - Resolve a binding we've already looked up, owner is the container it was bound to
+func (container *ContainerInstance) makeFromFoundBinding(res *resolution, step ResolutionStep, containerBinding *Binding, owner *ContainerInstance, parameters ...any) (any, error) {
+	if err := res.enter(step, containerBinding); err != nil {
+		return nil, err
+	}
+	defer res.leave()
+
+	resolver := container
+	if containerBinding.isSingleton {
+		resolver = owner
+	}
+
+	resolved, err := resolver.resolve(res, containerBinding, parameters...)
+	if err != nil {
+		return nil, res.fail(nil, err)
+	}
+
+	return resolved, nil
+}
+
+// lookupType - The type we key consumers, abstracts & extenders by, interfaces are used as is,
+// pointers are de-referenced and functions use their return type, in the same way as Make
+func lookupType(typ reflect.Type) reflect.Type {
+	if abstractType := getAbstractReturnType(typ); abstractType != nil {
+		return abstractType
+	}
+	if typ.Kind() == reflect.Func && typ.NumOut() == 0 {
 		return nil
 	}
 
-	return container.resolve(containerBinding, parameters...)
+	return getConcreteReturnType(typ)
+}
+
+// assignableValue - Convert a resolved value to a reflect.Value which can be assigned to typ
+// Concrete bindings are instantiated as a pointer, so we'll de-reference it when typ isn't a pointer
+func assignableValue(resolved any, typ reflect.Type) (reflect.Value, error) {
+	value := reflect.New(typ).Elem()
+
+	if resolved == nil {
+		return value, nil
+	}
+
+	resolvedValue := reflect.ValueOf(resolved)
+
+	if !resolvedValue.Type().AssignableTo(typ) && resolvedValue.Kind() == reflect.Ptr {
+		resolvedValue = resolvedValue.Elem()
+	}
+
+	if !resolvedValue.Type().AssignableTo(typ) {
+		return value, newError(
+			ErrInvalidTarget,
+			typ,
+			"resolved value of type "+resolvedValue.Type().String()+" is not assignable",
+			nil,
+		)
+	}
+
+	value.Set(resolvedValue)
+
+	return value, nil
 }
 
 func (container *ContainerInstance) pointer() unsafe.Pointer {
@@ -0,0 +1,180 @@
+package container
+
+import (
+	"reflect"
+)
+
+// resolvingHooks - The callbacks registered for one stage of resolving, Resolving or AfterResolving
+// container.mu guards them
+type resolvingHooks struct {
+	// Callbacks for every binding, registered with ResolvingAny/AfterResolvingAny
+	any []reflect.Value
+
+	// Callbacks keyed by the type they were registered for, in the order they were registered
+	typed map[reflect.Type][]reflect.Value
+}
+
+// Resolving - Register a callback which is called when abstract is resolved, it receives the
+// new instance & the container that resolved it, this is useful for config injection or setup
+//
+// For example:
+//  Container.Resolving((*Mailer)(nil), func(mailer Mailer, c *container.ContainerInstance) {
+//  	mailer.SetFrom("noreply@example.com")
+//  })
+//
+// The callback can also return an error, which fails the resolution with ErrConstructorFailed
+// Callbacks are called each time the container creates an instance, singletons & scoped
+// bindings only call them when they're first created, not when the cached instance is returned.
+// Callbacks registered on a parent container are also called for resolutions in its children.
+func (container *ContainerInstance) Resolving(abstract any, callback any) bool {
+	return logError(container.ResolvingE(abstract, callback))
+}
+
+// ResolvingE - The same as Resolving, but returns an ErrInvalidBinding error when the callback can't be registered
+func (container *ContainerInstance) ResolvingE(abstract any, callback any) error {
+	return container.addTypedHook(&container.resolving, abstract, callback)
+}
+
+// AfterResolving - The same as Resolving, but the callback is called after all the Resolving callbacks
+func (container *ContainerInstance) AfterResolving(abstract any, callback any) bool {
+	return logError(container.AfterResolvingE(abstract, callback))
+}
+
+// AfterResolvingE - The same as AfterResolving, but returns an ErrInvalidBinding error when the callback can't be registered
+func (container *ContainerInstance) AfterResolvingE(abstract any, callback any) error {
+	return container.addTypedHook(&container.afterResolving, abstract, callback)
+}
+
+// ResolvingAny - The same as Resolving, but the callback is called for every binding
+// These are called before the Resolving callbacks for the type
+func (container *ContainerInstance) ResolvingAny(callback func(instance any, container *ContainerInstance)) {
+	container.addAnyHook(&container.resolving, callback)
+}
+
+// AfterResolvingAny - The same as AfterResolving, but the callback is called for every binding
+// These are called before the AfterResolving callbacks for the type
+func (container *ContainerInstance) AfterResolvingAny(callback func(instance any, container *ContainerInstance)) {
+	container.addAnyHook(&container.afterResolving, callback)
+}
+
+func (container *ContainerInstance) addAnyHook(hooks *resolvingHooks, callback func(any, *ContainerInstance)) {
+	if callback == nil {
+		return
+	}
+
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
+	hooks.any = append(hooks.any, reflect.ValueOf(callback))
+}
+
+func (container *ContainerInstance) addTypedHook(hooks *resolvingHooks, abstract any, callback any) error {
+	if abstract == nil {
+		return newError(ErrInvalidBinding, nil, "resolving callbacks require an abstract", nil)
+	}
+
+	abstractType := lookupType(getType(abstract))
+	if abstractType == nil {
+		return newError(ErrInvalidBinding, getType(abstract), "failed to get type of abstract", nil)
+	}
+
+	callbackValue, err := validateHook(abstractType, callback)
+	if err != nil {
+		return err
+	}
+
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
+	if hooks.typed == nil {
+		hooks.typed = make(map[reflect.Type][]reflect.Value)
+	}
+	hooks.typed[abstractType] = append(hooks.typed[abstractType], callbackValue)
+
+	return nil
+}
+
+// validateHook - Check callback is a func(T, *ContainerInstance) or func(T, *ContainerInstance) error,
+// where T is what abstractType resolves to
+func validateHook(abstractType reflect.Type, callback any) (reflect.Value, error) {
+	if callback == nil {
+		return reflect.Value{}, newError(ErrInvalidBinding, abstractType, "resolving callbacks require a function", nil)
+	}
+
+	callbackType := getType(callback)
+
+	if callbackType.Kind() != reflect.Func ||
+		callbackType.NumIn() != 2 ||
+		callbackType.In(1) != containerInstanceType ||
+		callbackType.NumOut() > 1 ||
+		(callbackType.NumOut() == 1 && callbackType.Out(0) != errorType) {
+		return reflect.Value{}, newError(
+			ErrInvalidBinding,
+			callbackType,
+			"resolving callback must be a func(T, *ContainerInstance) or func(T, *ContainerInstance) error",
+			nil,
+		)
+	}
+
+	if instanceType := callbackType.In(0); instanceType != abstractType && pointerElemType(instanceType) != abstractType {
+		return reflect.Value{}, newError(
+			ErrInvalidBinding,
+			callbackType,
+			"resolving callback can't receive "+abstractType.String(),
+			nil,
+		)
+	}
+
+	return getVal(callback), nil
+}
+
+// finishResolving - Called with every instance the container creates, before it's returned or cached
+// The binding's extenders are applied, then the Resolving & AfterResolving callbacks are called
+func (container *ContainerInstance) finishResolving(binding *Binding, instance any) (any, error) {
+	instance, err := container.applyExtenders(binding, instance)
+	if err != nil || instance == nil {
+		return instance, err
+	}
+
+	if err := container.callHooks(binding, instance, func(c *ContainerInstance) *resolvingHooks { return &c.resolving }); err != nil {
+		return nil, err
+	}
+	if err := container.callHooks(binding, instance, func(c *ContainerInstance) *resolvingHooks { return &c.afterResolving }); err != nil {
+		return nil, err
+	}
+
+	return instance, nil
+}
+
+// callHooks - Call the callbacks for one stage, from our root container down to this one
+// For each container, the callbacks for every binding are called, then the ones for the binding's type
+func (container *ContainerInstance) callHooks(binding *Binding, instance any, stage func(c *ContainerInstance) *resolvingHooks) error {
+	var callbacks []reflect.Value
+
+	for c := container; c != nil; c = c.ParentContainer() {
+		c.mu.RLock()
+		hooks := stage(c)
+		containerCallbacks := append([]reflect.Value{}, hooks.any...)
+		if binding.registeredType != nil {
+			containerCallbacks = append(containerCallbacks, hooks.typed[binding.registeredType]...)
+		}
+		c.mu.RUnlock()
+
+		callbacks = append(containerCallbacks, callbacks...)
+	}
+
+	for _, callback := range callbacks {
+		instanceValue, err := assignableValue(instance, callback.Type().In(0))
+		if err != nil {
+			return err
+		}
+
+		results := callback.Call([]reflect.Value{instanceValue, reflect.ValueOf(container)})
+
+		if len(results) == 1 && !results[0].IsNil() {
+			return newError(ErrConstructorFailed, binding.registeredType, "resolving callback failed", results[0].Interface().(error))
+		}
+	}
+
+	return nil
+}
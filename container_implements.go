@@ -0,0 +1,129 @@
+package container
+
+import (
+	"reflect"
+	"strings"
+)
+
+// checkGives - Check what a binding gives can be used where abstract is needed
+// Returns an ErrInvalidBinding error explaining why not, listing the methods givenType is missing
+//
+// givenType is the struct the container instantiates, a resolver function's return type, or an instance's type.
+// The container always instantiates a pointer to a struct, so its methods can have either receiver. When exact
+// is true, givenType is what's resolved, so a value can't use the methods which have pointer receivers.
+func checkGives(givenType reflect.Type, abstract reflect.Type, exact bool) error {
+	if givenType == abstract {
+		return nil
+	}
+
+	if abstract.Kind() != reflect.Interface {
+		if pointerElemType(givenType) == abstract {
+			return nil
+		}
+
+		return newError(ErrInvalidBinding, givenType, "can't be used as "+abstract.String(), nil)
+	}
+
+	if !exact && givenType.Kind() != reflect.Pointer {
+		givenType = reflect.PointerTo(givenType)
+	}
+
+	if givenType.Implements(abstract) {
+		return nil
+	}
+
+	// Reflection can't see unexported methods of concrete types, so they're only described as missing
+	message := "doesn't implement " + abstract.String()
+	if problems := missingMethods(givenType, abstract); len(problems) > 0 {
+		message += ": " + strings.Join(problems, ", ")
+	}
+
+	return newError(ErrInvalidBinding, givenType, message, nil)
+}
+
+// missingMethods - Describe each method of abstract which givenType doesn't have, or has with the wrong signature
+func missingMethods(givenType reflect.Type, abstract reflect.Type) []string {
+	var problems []string
+
+	for i := 0; i < abstract.NumMethod(); i++ {
+		abstractMethod := abstract.Method(i)
+
+		method, ok := givenType.MethodByName(abstractMethod.Name)
+		if !ok {
+			if givenType.Kind() != reflect.Pointer && givenType.Kind() != reflect.Interface {
+				if _, ok := reflect.PointerTo(givenType).MethodByName(abstractMethod.Name); ok {
+					problems = append(problems, "method "+abstractMethod.Name+" has a pointer receiver, use *"+givenType.String())
+					continue
+				}
+			}
+
+			problems = append(problems, "missing method "+abstractMethod.Name)
+			continue
+		}
+
+		// Interface methods don't have a receiver, but the methods of other types do
+		methodType := method.Type
+		if givenType.Kind() != reflect.Interface {
+			methodType = withoutReceiver(methodType)
+		}
+
+		if methodType != abstractMethod.Type {
+			problems = append(
+				problems,
+				"method "+abstractMethod.Name+" is "+methodType.String()+", it should be "+abstractMethod.Type.String(),
+			)
+		}
+	}
+
+	return problems
+}
+
+// withoutReceiver - The type of a method, without the receiver as its first arg
+func withoutReceiver(methodType reflect.Type) reflect.Type {
+	in := make([]reflect.Type, 0, methodType.NumIn()-1)
+	for i := 1; i < methodType.NumIn(); i++ {
+		in = append(in, methodType.In(i))
+	}
+
+	out := make([]reflect.Type, 0, methodType.NumOut())
+	for i := 0; i < methodType.NumOut(); i++ {
+		out = append(out, methodType.Out(i))
+	}
+
+	return reflect.FuncOf(in, out, methodType.IsVariadic())
+}
+
+// checkGivesDefinition - checkGives, for a definition passed to Bind, a struct or a resolver function
+// A resolver function returning an interface could still return something which implements abstract,
+// so it's only checked when it's resolved, see checkResolved
+func checkGivesDefinition(definition any, abstract reflect.Type) error {
+	definitionType := getType(definition)
+
+	if definitionType.Kind() == reflect.Func {
+		if definitionType.NumOut() == 0 {
+			return newError(ErrInvalidBinding, definitionType, "resolver function doesn't have a return type", nil)
+		}
+		if definitionType.Out(0).Kind() == reflect.Interface {
+			return nil
+		}
+
+		return checkGives(definitionType.Out(0), abstract, true)
+	}
+
+	return checkGives(definitionType, abstract, false)
+}
+
+// checkBindingGives - checkGivesDefinition for a binding created from definition, which is registered as abstract
+// Every registration path uses it, so a resolver function is checked the same way however it's bound
+func checkBindingGives(binding *Binding, definition any, abstract reflect.Type) error {
+	if err := checkGivesDefinition(definition, abstract); err != nil {
+		return err
+	}
+
+	// checkResolved checks what the resolver function returns against the binding's abstract
+	if binding.isFunctionResolver {
+		binding.abstractType = abstract
+	}
+
+	return nil
+}
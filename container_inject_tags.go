@@ -0,0 +1,125 @@
+package container
+
+import (
+	"reflect"
+	"strings"
+)
+
+// injectTag - The parsed `inject` struct tag of a field
+//
+// The tag is a comma separated list of options:
+//  inject:""                     Inject the field, it's an error if its type isn't bound
+//  inject:"-"                    Never inject the field
+//  inject:"optional"             Inject the field if its type is bound, otherwise leave it alone
+//  inject:"name=replica"         Inject the binding named replica, see BindNamed
+//  inject:"replica"              Shorthand for name=replica
+//  inject:"tagged=StatServices"  Inject every binding tagged with StatServices, the field must be a slice
+//
+// Options can be combined, for example inject:"name=replica,optional"
+// Fields without an inject tag are injected when their type is bound, unless
+// ContainerConfig.OnlyInjectStructFieldsWithInjectTag is set, then they're skipped
+type injectTag struct {
+	skip     bool
+	optional bool
+	name     string
+	tagged   string
+}
+
+// parseInjectTag - Parse the inject tag of field, returns false when the field doesn't have one
+// A malformed tag returns an ErrInvalidTag error which describes the problem
+func parseInjectTag(field reflect.StructField) (injectTag, bool, error) {
+	value, ok := field.Tag.Lookup("inject")
+	if !ok {
+		return injectTag{}, false, nil
+	}
+
+	tag := injectTag{}
+
+	invalid := func(message string) (injectTag, bool, error) {
+		return injectTag{}, true, newError(
+			ErrInvalidTag,
+			field.Type,
+			"field "+field.Name+" `inject:\""+value+"\"`: "+message,
+			nil,
+		)
+	}
+
+	if value == "" {
+		return tag, true, nil
+	}
+	if value == "-" {
+		tag.skip = true
+		return tag, true, nil
+	}
+
+	for _, option := range strings.Split(value, ",") {
+		option = strings.TrimSpace(option)
+		key, optionValue, hasValue := strings.Cut(option, "=")
+
+		switch {
+		case option == "":
+			return invalid("empty option")
+		case option == "-":
+			return invalid("\"-\" can't be combined with other options")
+		case option == "optional":
+			if tag.optional {
+				return invalid("optional is set more than once")
+			}
+			tag.optional = true
+		case !hasValue:
+			// Any other option without a value is shorthand for name=option
+			if tag.name != "" {
+				return invalid("name is set more than once")
+			}
+			tag.name = option
+		case optionValue == "":
+			return invalid(key + " requires a value")
+		case key == "name":
+			if tag.name != "" {
+				return invalid("name is set more than once")
+			}
+			tag.name = optionValue
+		case key == "tagged":
+			if tag.tagged != "" {
+				return invalid("tagged is set more than once")
+			}
+			tag.tagged = optionValue
+		default:
+			return invalid("unknown option " + key)
+		}
+	}
+
+	if tag.name != "" && tag.tagged != "" {
+		return invalid("name and tagged can't be used together")
+	}
+	if tag.tagged != "" && field.Type.Kind() != reflect.Slice {
+		return invalid("tagged can only be used on a slice field")
+	}
+
+	return tag, true, nil
+}
+
+// validateInjectTags - Check every inject tag of a struct type is valid, so a malformed tag
+// is reported when the struct is bound, rather than the first time it's resolved
+// Types which aren't structs don't have any tags to check
+func validateInjectTags(typ reflect.Type) error {
+	if typ == nil {
+		return nil
+	}
+
+	structType := typ
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < structType.NumField(); i++ {
+		if _, _, err := parseInjectTag(structType.Field(i)); err != nil {
+			return newError(ErrInvalidBinding, structType, "", err)
+		}
+	}
+
+	return nil
+}
@@ -1,5 +1,7 @@
 package container
 
+import "reflect"
+
 // func (container *ContainerInstance) binding(abstract any) *Binding {
 // 	binding := container.getBindingType(abstract)
 //
@@ -25,14 +27,35 @@ package container
 // Call - Call the specified function via the container, you can add parameters to your function,
 // and they will be resolved from the container, if they're registered
 func (container *ContainerInstance) Call(function any, parameters ...any) []any {
+	returnResult, err := container.CallE(function, parameters...)
+	if !logError(err) {
+		return nil
+	}
+
+	return returnResult
+}
+
+// CallE - The same as Call, but the function won't be called if one of its args can't be resolved
+// from the container, instead the error is returned
+func (container *ContainerInstance) CallE(function any, parameters ...any) ([]any, error) {
+	if function == nil {
+		return nil, newError(ErrInvalidTarget, nil, "Call() requires a function", nil)
+	}
+	if functionType := getType(function); functionType.Kind() != reflect.Func {
+		return nil, newError(ErrInvalidTarget, functionType, "Call() requires a function", nil)
+	}
+
 	invocable := CreateInvocableFunction(function)
 
-	instanceReturnValues := invocable.CallMethodWith(container, parameters...)
+	instanceReturnValues, err := invocable.callMethodWith(container, newResolution(), parameters...)
+	if err != nil {
+		return nil, err
+	}
 
 	returnResult := make([]any, len(instanceReturnValues))
 	for i, value := range instanceReturnValues {
 		returnResult[i] = value.Interface()
 	}
 
-	return returnResult
+	return returnResult, nil
 }
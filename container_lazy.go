@@ -0,0 +1,162 @@
+package container
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Lazy - Depend on Lazy[T] instead of T, to resolve T the first time it's used, rather than when
+// your service is constructed. Constructors, Call targets and struct fields can all ask for one
+//
+// For example:
+//  func NewReportService(exporter container.Lazy[Exporter]) *ReportService {
+//  	return &ReportService{exporter: exporter}
+//  }
+//  ...
+//  service.exporter.Get().Export(report)
+//
+// T is resolved from the container which injected the Lazy(using any contextual binding for the
+// service it was injected into), once T resolves successfully the
+// same instance is returned every time. As T isn't resolved until it's used, a Lazy can also
+// be used to break construction order/circular dependencies between services. Using it from T's own
+// singleton constructor, while T is still being constructed, returns an ErrDeadlock error, other
+// goroutines using it in the meantime wait for T to be constructed.
+type Lazy[T any] struct {
+	state *lazyState
+}
+
+type lazyState struct {
+	mu       sync.Mutex
+	resolve  func() (any, error)
+	resolved bool
+	instance any
+}
+
+// lazyInjectable - Implemented by every Lazy[T], so we can create one from its reflect.Type
+type lazyInjectable interface {
+	lazyType() reflect.Type
+	withResolver(resolve func() (any, error)) any
+}
+
+var lazyInjectableType = reflect.TypeOf((*lazyInjectable)(nil)).Elem()
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Get - Resolve T, or return the instance we already resolved
+// If T fails to resolve, the error is logged and the zero value of T is returned
+func (lazy Lazy[T]) Get() T {
+	instance, err := lazy.GetE()
+	logError(err)
+
+	return instance
+}
+
+// GetE - The same as Get, but returns the error if T fails to resolve
+// If it fails, the next call to Get/GetE will try to resolve T again
+func (lazy Lazy[T]) GetE() (T, error) {
+	if lazy.state == nil {
+		var zero T
+		return zero, newError(ErrInvalidTarget, typeOf[T](), "Lazy was not injected by the container", nil)
+	}
+
+	instance, err := lazy.state.get()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return castTo[T](instance)
+}
+
+func (lazy Lazy[T]) lazyType() reflect.Type {
+	return typeOf[T]()
+}
+
+func (lazy Lazy[T]) withResolver(resolve func() (any, error)) any {
+	return Lazy[T]{state: &lazyState{resolve: resolve}}
+}
+
+func (state *lazyState) get() (any, error) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if state.resolved {
+		return state.instance, nil
+	}
+
+	instance, err := state.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	state.instance = instance
+	state.resolved = true
+
+	return instance, nil
+}
+
+// providerReturnType - If typ is a provider function, func() T or func() (T, error), returns T
+func providerReturnType(typ reflect.Type) (reflect.Type, bool) {
+	if typ.Kind() != reflect.Func || typ.NumIn() != 0 {
+		return nil, false
+	}
+
+	if typ.NumOut() == 1 || (typ.NumOut() == 2 && typ.Out(1) == errorType) {
+		return typ.Out(0), true
+	}
+
+	return nil, false
+}
+
+// resolveDeferred - If typ is a Lazy[T] or a provider function(func() T, func() (T, error))
+// we'll create one which resolves T from this container when it's used.
+//
+// Lazy[T] is always created, since T may be bound after we've been resolved.
+// Provider functions are only created when T is bound, so we don't replace unrelated func fields/args
+//
+// consumers are the types being built that T is injected into, so their contextual bindings are used
+//
+// Returns false when typ isn't something we can defer resolving
+func (container *ContainerInstance) resolveDeferred(consumers []reflect.Type, typ reflect.Type) (reflect.Value, bool) {
+	if typ.Implements(lazyInjectableType) {
+		lazy := reflect.Zero(typ).Interface().(lazyInjectable)
+		lazyType := lazy.lazyType()
+
+		return reflect.ValueOf(lazy.withResolver(func() (any, error) {
+			return container.makeDependency(consumers, lazyType)
+		})), true
+	}
+
+	providedType, ok := providerReturnType(typ)
+	if !ok || !container.isDependencyBound(consumers, providedType) {
+		return reflect.Value{}, false
+	}
+
+	// Every call to the provider resolves T again, so transient bindings give a new instance each call
+	return reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+		instance, err := container.makeValue(consumers, providedType)
+
+		if typ.NumOut() == 2 {
+			errValue := reflect.Zero(errorType)
+			if err != nil {
+				errValue = reflect.ValueOf(&err).Elem()
+			}
+
+			return []reflect.Value{instance, errValue}
+		}
+
+		logError(err)
+
+		return []reflect.Value{instance}
+	}), true
+}
+
+// makeValue - Resolve typ for the consumers & return it as a reflect.Value which can be assigned to typ
+func (container *ContainerInstance) makeValue(consumers []reflect.Type, typ reflect.Type) (reflect.Value, error) {
+	resolved, err := container.makeDependency(consumers, typ)
+	if err != nil {
+		return reflect.New(typ).Elem(), err
+	}
+
+	return assignableValue(resolved, typ)
+}
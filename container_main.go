@@ -2,106 +2,279 @@ package container
 
 import (
 	"reflect"
+	"sync"
+	"time"
 	"unsafe"
 )
 
 // ContainerConfig - Holds configuration values... soon I will add some more, make them work fully
-// Right now this is a placeholder
 type ContainerConfig struct {
 	OnlyInjectStructFieldsWithInjectTag bool
+
+	// SingletonErrorPolicy - What to do when a singleton's constructor returns an error
+	// Defaults to RetrySingletonErrors
+	SingletonErrorPolicy SingletonErrorPolicy
+
+	// SingletonWaitTimeout - How long to wait for a singleton which another goroutine is constructing
+	// When it's zero we'll wait forever, but a warning is logged if the wait looks like a deadlock
+	SingletonWaitTimeout time.Duration
+
+	// DisposeInstances - When true, Close will also dispose values bound with Instance()
+	// By default, they're left alone, since the container didn't create them
+	DisposeInstances bool
+
+	// DisposeTransients - When true, Close will also dispose the transient instances we created
+	// By default, only singletons & scoped instances are disposed. Transients are handed to the caller,
+	// so they're theirs to close, and tracking them would keep every one alive until Close
+	DisposeTransients bool
 }
 
+// SingletonErrorPolicy - Decides what happens after a singleton's constructor returns an error
+type SingletonErrorPolicy int
+
+const (
+	// RetrySingletonErrors - The error isn't cached, the next resolve will call the constructor again
+	RetrySingletonErrors SingletonErrorPolicy = iota
+
+	// MemoizeSingletonErrors - The error is cached, every resolve returns the same error until ClearInstances/Reset
+	MemoizeSingletonErrors
+)
+
 // ContainerInstance - Holds all of our container registration
+// It's safe to register & resolve bindings from multiple goroutines at the same time
 type ContainerInstance struct {
 	Config *ContainerConfig
 
+	// Guards all the maps below & parent
+	// It's never held while a binding is being resolved, since resolving can register/resolve more bindings
+	mu sync.RWMutex
+
 	// Store our singleton instances
 	// instances map[reflect.Type]*Binding
 
-	// Our resolved singleton instances
-	resolved map[reflect.Type]any
+	// Our resolved singleton instances, and scoped instances resolved via this container
+	// Keyed by binding, since a scoped binding from our parent is cached here
+	resolved map[*Binding]any
+
+	// Singletons which are currently being constructed, anyone else resolving them will wait
+	pending map[*Binding]*pendingSingleton
+
+	// Singleton constructor errors, only used with MemoizeSingletonErrors
+	failed map[*Binding]error
 
 	// Store our abstract -> concrete bindings
 	// If a type doesn't have an abstract type
 	// We'll store concrete -> concrete
 	bindings map[reflect.Type]*Binding
 
+	// Bindings registered with a name, via BindNamed/SingletonNamed
+	// These are only resolved when they're requested by name
+	named map[namedBindingKey]*Binding
+
+	// Bindings registered with When().Needs().Give(), used instead of the abstract's own
+	// binding when one of the consumers is being built
+	contextual map[contextualBindingKey]*Binding
+
+	// Implementations added with BindMany/Append, keyed by the abstract they implement
+	// They're resolved together, in order, when a []abstract is needed
+	many map[reflect.Type][]*Binding
+
 	// Store aliases of Concrete -> Abstract, so we can resolve from concrete
 	// when we only bound Abstract -> Concrete
 	concretes map[reflect.Type]reflect.Type
 
+	// Extenders registered with Extend, keyed by the type they extend, in the order they were registered
+	extenders map[reflect.Type][]reflect.Value
+
+	// Callbacks registered with Resolving/ResolvingAny & AfterResolving/AfterResolvingAny
+	resolving      resolvingHooks
+	afterResolving resolvingHooks
+
+	// Bindings swapped in with Swap/Fake which haven't been restored yet, in the order they were swapped
+	swaps map[reflect.Type][]*swap
+
+	// Callbacks registered with Rebinding/Refresh, called when the type's binding is replaced
+	rebinding map[reflect.Type][]reflect.Value
+
 	// When we register a tagged type, we'll store the tag string and then an array
 	// of types for this tag, we can then use these types to resolve the bindings
 	tagged map[string][]reflect.Type
 
+	// Tags removed with Untag, which hides the bindings our parents tagged from this container
+	untagged map[string]*tagRemoval
+
+	// Instances we created which implement io.Closer or Disposable, in the order they were created
+	// Close disposes them in reverse order
+	disposables []trackedDisposable
+
 	// If our container is a child container, we'll have a pointer to our parent
 	parent *ContainerInstance
+
+	// Child containers created from this container which hold instances, Close closes them first
+	// A child is only added once it caches an instance, see attachToParent
+	children []*ContainerInstance
+
+	// Whether we're in our parent's children
+	attached bool
 }
 
 // CreateContainer - Create a new container instance
 func CreateContainer() *ContainerInstance {
-	c := &ContainerInstance{
-		Config: &ContainerConfig{OnlyInjectStructFieldsWithInjectTag: false},
-
-		resolved:  make(map[reflect.Type]any),
-		bindings:  make(map[reflect.Type]*Binding),
-		concretes: make(map[reflect.Type]reflect.Type),
-		tagged:    make(map[string][]reflect.Type),
-	}
+	c := newContainerInstance()
 
-	containerInstances = append(containerInstances, c.pointer())
+	registerContainerInstance(c)
 
 	return c
 }
 
+// newContainerInstance - Create an empty container with all of its maps ready to use
+func newContainerInstance() *ContainerInstance {
+	return &ContainerInstance{
+		Config: &ContainerConfig{OnlyInjectStructFieldsWithInjectTag: false},
+
+		resolved:   make(map[*Binding]any),
+		pending:    make(map[*Binding]*pendingSingleton),
+		failed:     make(map[*Binding]error),
+		bindings:   make(map[reflect.Type]*Binding),
+		named:      make(map[namedBindingKey]*Binding),
+		contextual: make(map[contextualBindingKey]*Binding),
+		many:       make(map[reflect.Type][]*Binding),
+		concretes:  make(map[reflect.Type]reflect.Type),
+		tagged:     make(map[string][]reflect.Type),
+		untagged:   make(map[string]*tagRemoval),
+		extenders:  make(map[reflect.Type][]reflect.Value),
+		rebinding:  make(map[reflect.Type][]reflect.Value),
+		swaps:      make(map[reflect.Type][]*swap),
+	}
+}
+
 var Container = CreateContainer()
 var containerInstances = []unsafe.Pointer{}
+var containerInstancesMu sync.Mutex
+
+// registerContainerInstance - Keep track of every root container we create
+// Child containers aren't tracked, so they can be garbage collected once they're no longer used
+func registerContainerInstance(container *ContainerInstance) {
+	containerInstancesMu.Lock()
+	defer containerInstancesMu.Unlock()
+
+	containerInstances = append(containerInstances, container.pointer())
+}
 
 // CreateChildContainer - Returns a new container, any failed look-ups of our
 // child container, will then be looked up in the parent, or returned nil
+//
+// The parent only holds on to the child once the child caches an instance, a scoped instance, a singleton
+// bound to the child or an Instance() value, so it can be closed, or evicted when the parent's bindings change.
+// From then on, it's kept until it's closed or reset, so always Close a child container once you're done with
+// it, for example at the end of each request. A child which never caches anything doesn't need closing.
 func (container *ContainerInstance) CreateChildContainer() *ContainerInstance {
-	c := &ContainerInstance{
-		Config:    &ContainerConfig{OnlyInjectStructFieldsWithInjectTag: false},
-		resolved:  make(map[reflect.Type]any),
-		bindings:  make(map[reflect.Type]*Binding),
-		concretes: make(map[reflect.Type]reflect.Type),
-		tagged:    make(map[string][]reflect.Type),
-	}
+	c := newContainerInstance()
 
 	c.parent = container
 
-	containerInstances = append(containerInstances, c.pointer())
-
 	return c
 }
 
+// attachToParent - Add us to our parent's children, once we hold instances our parent needs to reach
+// Our parent is attached to its own parent too, so closing the root container reaches us
+func (container *ContainerInstance) attachToParent() {
+	container.mu.Lock()
+	parent := container.parent
+	if parent == nil || container.attached {
+		container.mu.Unlock()
+		return
+	}
+	container.attached = true
+	container.mu.Unlock()
+
+	parent.mu.Lock()
+	parent.children = append(parent.children, container)
+	parent.mu.Unlock()
+
+	parent.attachToParent()
+}
+
+// detachFromParent - Remove us from our parent's children, once we've been closed or reset
+func (container *ContainerInstance) detachFromParent() {
+	container.mu.Lock()
+	parent := container.parent
+	container.attached = false
+	container.mu.Unlock()
+
+	if parent != nil {
+		parent.removeChild(container)
+	}
+}
+
 // ClearInstances - This will just remove any singleton instances from the container
 // When they are next resolved via Make/MakeTo, they will be instantiated again
+// On a child container, this releases the scoped instances it holds, the parents singletons aren't touched
 func (container *ContainerInstance) ClearInstances() {
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
 	for k := range container.resolved {
 		delete(container.resolved, k)
 	}
+	for k := range container.failed {
+		delete(container.failed, k)
+	}
 }
 
 // Reset - Reset will empty all bindings in this container, you will have to register
 // any bindings again before you can resolve them.
 func (container *ContainerInstance) Reset() {
+	container.detachFromParent()
+
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
 	for k := range container.resolved {
 		delete(container.resolved, k)
 	}
+	for k := range container.failed {
+		delete(container.failed, k)
+	}
 	for k := range container.bindings {
 		delete(container.bindings, k)
 	}
+	for k := range container.named {
+		delete(container.named, k)
+	}
+	for k := range container.contextual {
+		delete(container.contextual, k)
+	}
+	for k := range container.many {
+		delete(container.many, k)
+	}
 	for k := range container.concretes {
 		delete(container.concretes, k)
 	}
 	for k := range container.tagged {
 		delete(container.tagged, k)
 	}
+	for k := range container.untagged {
+		delete(container.untagged, k)
+	}
+	for k := range container.extenders {
+		delete(container.extenders, k)
+	}
+	for k := range container.rebinding {
+		delete(container.rebinding, k)
+	}
+	for k := range container.swaps {
+		delete(container.swaps, k)
+	}
+	container.resolving = resolvingHooks{}
+	container.afterResolving = resolvingHooks{}
 	container.parent = nil
 }
 
 // ParentContainer - Returns the parent container, if one exists
 func (container *ContainerInstance) ParentContainer() *ContainerInstance {
+	container.mu.RLock()
+	defer container.mu.RUnlock()
+
 	return container.parent
 }
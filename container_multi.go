@@ -0,0 +1,167 @@
+package container
+
+import (
+	"reflect"
+)
+
+// BindMany - Bind several implementations of the same abstract, which are injected together as a []abstract
+// This replaces any implementations previously added to the abstract in this container, use Append to add to them
+// Each concrete accepts the same definitions as Bind with a single arg, a function or a struct type
+//
+// For example:
+//  Container.BindMany((*HealthCheck)(nil), NewDatabaseCheck, NewCacheCheck)
+//  Container.Append((*HealthCheck)(nil), new(DiskCheck))
+//
+//  func NewHealthController(checks []HealthCheck) *HealthController { ... }
+//
+// Constructors, Call targets & struct fields which need a []HealthCheck get every implementation,
+// our root container's first, then each child container's, in the order they were added.
+// Each implementation is resolved by the container resolving the slice, so child overrides apply.
+// Calling BindMany with no concretes declares an empty group, so []HealthCheck can be injected before any are added.
+func (container *ContainerInstance) BindMany(abstract any, concretes ...any) bool {
+	return logError(container.BindManyE(abstract, concretes...))
+}
+
+// BindManyE - The same as BindMany, but returns an ErrInvalidBinding error when the implementations can't be registered
+// If any of the concretes are invalid, nothing is registered
+func (container *ContainerInstance) BindManyE(abstract any, concretes ...any) error {
+	return container.addManyBindings(abstract, concretes, true)
+}
+
+// Append - Add another implementation of abstract, see BindMany
+func (container *ContainerInstance) Append(abstract any, concrete any) bool {
+	return logError(container.AppendE(abstract, concrete))
+}
+
+// AppendE - The same as Append, but returns an ErrInvalidBinding error when the implementation can't be registered
+func (container *ContainerInstance) AppendE(abstract any, concrete any) error {
+	return container.addManyBindings(abstract, []any{concrete}, false)
+}
+
+// addManyBindings - Create the bindings for BindMany/Append, when replace is true, they replace our existing ones
+func (container *ContainerInstance) addManyBindings(abstract any, concretes []any, replace bool) error {
+	if abstract == nil {
+		return newError(ErrInvalidBinding, nil, "BindMany() requires an abstract", nil)
+	}
+
+	abstractType := lookupType(getType(abstract))
+	if abstractType == nil {
+		return newError(ErrInvalidBinding, getType(abstract), "failed to get type of abstract", nil)
+	}
+
+	bindings := make([]*Binding, 0, len(concretes))
+
+	for _, concrete := range concretes {
+		_, binding, err := createBinding([]any{concrete})
+		if err != nil {
+			return err
+		}
+
+		if err := checkBindingGives(binding, concrete, abstractType); err != nil {
+			return err
+		}
+
+		binding.registeredType = abstractType
+		bindings = append(bindings, binding)
+	}
+
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
+	if replace {
+		container.many[abstractType] = bindings
+		return nil
+	}
+
+	container.many[abstractType] = append(container.many[abstractType], bindings...)
+
+	return nil
+}
+
+// ownedBinding - A binding & the container it was bound to
+type ownedBinding struct {
+	binding *Binding
+	owner   *ContainerInstance
+}
+
+// findManyBindings - Collect the implementations added to elemType with BindMany/Append
+// Our root container's come first, then each child's, returns false if none of the containers declared elemType
+func (container *ContainerInstance) findManyBindings(elemType reflect.Type) ([]ownedBinding, bool) {
+	var found []ownedBinding
+	declared := false
+
+	for c := container; c != nil; c = c.ParentContainer() {
+		c.mu.RLock()
+		bindings, ok := c.many[elemType]
+		c.mu.RUnlock()
+
+		if !ok {
+			continue
+		}
+
+		declared = true
+
+		owned := make([]ownedBinding, len(bindings))
+		for i, binding := range bindings {
+			owned[i] = ownedBinding{binding: binding, owner: c}
+		}
+		found = append(owned, found...)
+	}
+
+	return found, declared
+}
+
+// resolveMany - Resolve every implementation added with BindMany/Append into a slice of sliceType
+// Returns false when sliceType isn't a slice, or its element type was never added with BindMany/Append
+func (container *ContainerInstance) resolveMany(res *resolution, site resolutionSite, sliceType reflect.Type) (any, bool, error) {
+	if sliceType.Kind() != reflect.Slice {
+		return nil, false, nil
+	}
+
+	elemType := lookupType(sliceType.Elem())
+	if elemType == nil {
+		return nil, false, nil
+	}
+
+	bindings, declared := container.findManyBindings(elemType)
+	if !declared {
+		return nil, false, nil
+	}
+
+	resolvedSlice := reflect.MakeSlice(sliceType, 0, len(bindings))
+
+	for _, owned := range bindings {
+		resolved, err := container.makeFromFoundBinding(res, site.step(elemType, owned.binding), owned.binding, owned.owner)
+		if err != nil {
+			return nil, true, err
+		}
+		if resolved == nil {
+			continue
+		}
+
+		value, err := assignableValue(resolved, sliceType.Elem())
+		if err != nil {
+			return nil, true, err
+		}
+
+		resolvedSlice = reflect.Append(resolvedSlice, value)
+	}
+
+	return resolvedSlice.Interface(), true, nil
+}
+
+// isManyBound - Check if resolveMany can resolve sliceType
+func (container *ContainerInstance) isManyBound(sliceType reflect.Type) bool {
+	if sliceType.Kind() != reflect.Slice {
+		return false
+	}
+
+	elemType := lookupType(sliceType.Elem())
+	if elemType == nil {
+		return false
+	}
+
+	_, declared := container.findManyBindings(elemType)
+
+	return declared
+}
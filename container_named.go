@@ -0,0 +1,228 @@
+package container
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// NamedArgs - Maps a function arg index to the name of the binding it should be resolved from
+// It can be passed anywhere function args are resolved, Bind, Singleton, Scoped, Call & Make, with the rest
+// of the definition/parameters. Args which aren't in the map are resolved as usual.
+//
+// For example:
+//  Container.BindNamed("replica", NewReplicaDatabase)
+//  Container.Bind(NewReportService, container.NamedArgs{0: "replica"})
+//  Container.Call(func(primary Database, replica Database) {}, container.NamedArgs{1: "replica"})
+type NamedArgs map[int]string
+
+// namedBindingKey - Named bindings are keyed by their name & the type they'd be registered under without one
+type namedBindingKey struct {
+	name        string
+	bindingType reflect.Type
+}
+
+// BindNamed - The same as Bind, but the binding is registered under a name, so we can have
+// several implementations of the same type, for example, a primary & replica Database
+// The binding is only resolved by asking for it by name, via MakeNamed, an `inject:"name"`
+// struct tag or NamedArgs
+//
+// For example:
+//  Container.BindNamed("primary", NewPrimaryDatabase)
+//  Container.BindNamed("replica", NewReplicaDatabase)
+//  replica := Container.MakeNamed("replica", (*Database)(nil)).(Database)
+func (container *ContainerInstance) BindNamed(name string, bindingDef ...any) bool {
+	return logError(container.BindNamedE(name, bindingDef...))
+}
+
+// BindNamedE - The same as BindNamed, but returns an ErrInvalidBinding error when the binding can't be registered
+func (container *ContainerInstance) BindNamedE(name string, bindingDef ...any) error {
+	if name == "" {
+		return newError(ErrInvalidBinding, nil, "BindNamed() requires a name", nil)
+	}
+
+	bindingType, binding, err := createBinding(bindingDef)
+	if err != nil {
+		return err
+	}
+
+	container.addNamedBinding(name, bindingType, binding)
+
+	return nil
+}
+
+// SingletonNamed - The same as Singleton, but the singleton is registered under a name
+// Each name gets its own instance
+func (container *ContainerInstance) SingletonNamed(name string, singleton any, concreteResolverFunc ...any) bool {
+	return logError(container.SingletonNamedE(name, singleton, concreteResolverFunc...))
+}
+
+// SingletonNamedE - The same as SingletonNamed, but returns an ErrInvalidBinding error when the singleton can't be registered
+func (container *ContainerInstance) SingletonNamedE(name string, singleton any, concreteResolverFunc ...any) error {
+	if name == "" {
+		return newError(ErrInvalidBinding, nil, "SingletonNamed() requires a name", nil)
+	}
+
+	singletonType, binding, err := createSharedBinding("Singleton", singleton, concreteResolverFunc)
+	if err != nil {
+		return err
+	}
+
+	binding.isSingleton = true
+	container.addNamedBinding(name, singletonType, binding)
+
+	return nil
+}
+
+// IsBoundNamed - Check if a binding with this name exists for the provided value type, in our container or our parents
+func (container *ContainerInstance) IsBoundNamed(name string, binding any) bool {
+	if binding == nil {
+		return false
+	}
+
+	containerBinding, _, _ := container.findNamedBinding(name, getType(binding))
+
+	return containerBinding != nil
+}
+
+// MakeNamed - The same as Make, but resolves the binding registered under name
+// For example:
+//  replica := ContainerInstance.MakeNamed("replica", (*Database)(nil)).(Database)
+func (container *ContainerInstance) MakeNamed(name string, abstract any, parameters ...any) any {
+	resolved, err := container.MakeNamedE(name, abstract, parameters...)
+	if !logError(err) {
+		return nil
+	}
+
+	return resolved
+}
+
+// MakeNamedE - The same as MakeNamed, but returns an error instead of logging and returning nil
+func (container *ContainerInstance) MakeNamedE(name string, abstract any, parameters ...any) (any, error) {
+	if abstract == nil {
+		return nil, newError(ErrInvalidTarget, nil, "cannot make a nil abstract", nil)
+	}
+
+	return container.makeNamed(newResolution(), topLevelSite, name, getType(abstract), parameters...)
+}
+
+// addNamedBinding - The same as addBinding, for bindings registered under a name
+func (container *ContainerInstance) addNamedBinding(name string, bindingType reflect.Type, binding *Binding) {
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
+	binding.registeredType = bindingType
+	container.named[namedBindingKey{name: name, bindingType: bindingType}] = binding
+}
+
+// getOwnNamedBinding - Look up a named binding in this container only
+// Named bindings have to be requested by the type they were bound under, so we only try the abstract
+// & concrete versions of bindingType, there's no reverse lookup from concretes like getOwnBindingType
+func (container *ContainerInstance) getOwnNamedBinding(name string, bindingType reflect.Type) (*Binding, reflect.Type) {
+	container.mu.RLock()
+	defer container.mu.RUnlock()
+
+	for _, testType := range []reflect.Type{getAbstractReturnType(bindingType), getConcreteReturnType(bindingType)} {
+		if testType == nil {
+			continue
+		}
+
+		if binding, ok := container.named[namedBindingKey{name: name, bindingType: testType}]; ok {
+			return binding, testType
+		}
+	}
+
+	return nil, nil
+}
+
+// findNamedBinding - Look up a named binding in this container, then our parents
+// Returns the binding, the type it was bound under & the container it was bound to
+func (container *ContainerInstance) findNamedBinding(name string, bindingType reflect.Type) (*Binding, reflect.Type, *ContainerInstance) {
+	for c := container; c != nil; c = c.ParentContainer() {
+		if binding, boundType := c.getOwnNamedBinding(name, bindingType); binding != nil {
+			return binding, boundType, c
+		}
+	}
+
+	return nil, nil, nil
+}
+
+// makeNamed - The same as makeFromBinding, but for the binding registered under name
+func (container *ContainerInstance) makeNamed(res *resolution, site resolutionSite, name string, bindingType reflect.Type, parameters ...any) (any, error) {
+	site = site.named(name)
+
+	containerBinding, boundType, owner := container.findNamedBinding(name, bindingType)
+	if containerBinding == nil {
+		if interfaceType := getAbstractReturnType(bindingType); interfaceType != nil {
+			bindingType = interfaceType
+		}
+
+		step := site.step(bindingType, nil)
+		return nil, res.fail(&step, newError(ErrNotBound, bindingType, "no binding named \""+name+"\"", nil))
+	}
+
+	return container.makeFromFoundBinding(res, site.step(boundType, containerBinding), containerBinding, owner, parameters...)
+}
+
+// setNamedArgs - Validate & store the NamedArgs which were provided with the binding definition
+// They can only be used with function bindings, since they refer to the function's args
+func (binding *Binding) setNamedArgs(namedArgs NamedArgs) error {
+	if len(namedArgs) == 0 {
+		return nil
+	}
+
+	if !binding.isFunctionResolver {
+		return newError(ErrInvalidBinding, binding.concreteType, "NamedArgs can only be used with function bindings", nil)
+	}
+
+	functionType := reflect.TypeOf(binding.resolverFunction)
+	for index, name := range namedArgs {
+		if index < 0 || index >= functionType.NumIn() {
+			return newError(
+				ErrInvalidBinding,
+				functionType,
+				"NamedArgs index "+strconv.Itoa(index)+" is not an arg of the function",
+				nil,
+			)
+		}
+		if name == "" {
+			return newError(ErrInvalidBinding, functionType, "NamedArgs index "+strconv.Itoa(index)+" has an empty name", nil)
+		}
+	}
+
+	binding.namedArgs = namedArgs
+
+	return nil
+}
+
+// splitNamedArgs - Remove any NamedArgs from values & merge them, later values override earlier ones
+// If values doesn't contain any NamedArgs, it's returned as is
+func splitNamedArgs(values []any) ([]any, NamedArgs) {
+	var namedArgs NamedArgs
+	var remaining []any
+
+	for i, value := range values {
+		args, ok := value.(NamedArgs)
+		if !ok {
+			if remaining != nil {
+				remaining = append(remaining, value)
+			}
+			continue
+		}
+
+		if remaining == nil {
+			remaining = append(make([]any, 0, len(values)), values[:i]...)
+		}
+		if namedArgs == nil {
+			namedArgs = NamedArgs{}
+		}
+		for index, name := range args {
+			namedArgs[index] = name
+		}
+	}
+
+	if namedArgs == nil {
+		return values, nil
+	}
+
+	return remaining, namedArgs
+}
@@ -0,0 +1,174 @@
+package container
+
+import (
+	"reflect"
+)
+
+var anyType = reflect.TypeOf((*any)(nil)).Elem()
+
+// Rebinding - Register a callback which is called when abstract is bound again, for example with
+// Bind/Singleton/Instance after credentials are rotated. The callback receives the container &
+// a new instance resolved from the new binding, so anything holding the old instance can be updated
+//
+// For example:
+//  Container.Rebinding((*Credentials)(nil), func(c *container.ContainerInstance, credentials Credentials) {
+//  	client.SetCredentials(credentials)
+//  })
+//
+// Callbacks are only called for bindings replaced in this container
+// Replacing a binding always evicts its cached singleton, from this container & its children
+func (container *ContainerInstance) Rebinding(abstract any, callback any) bool {
+	return logError(container.RebindingE(abstract, callback))
+}
+
+// RebindingE - The same as Rebinding, but returns an ErrInvalidBinding error when the callback can't be registered
+func (container *ContainerInstance) RebindingE(abstract any, callback any) error {
+	if abstract == nil {
+		return newError(ErrInvalidBinding, nil, "Rebinding() requires an abstract", nil)
+	}
+
+	abstractType := lookupType(getType(abstract))
+	if abstractType == nil {
+		return newError(ErrInvalidBinding, getType(abstract), "failed to get type of abstract", nil)
+	}
+
+	callbackValue, err := validateRebindingCallback(abstractType, callback)
+	if err != nil {
+		return err
+	}
+
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
+	container.rebinding[abstractType] = append(container.rebinding[abstractType], callbackValue)
+
+	return nil
+}
+
+// Refresh - Call method on target with the new instance, whenever abstract is bound again
+// Returns the current instance of abstract, so it can be passed to target straight away
+//
+// For example:
+//  credentials := Container.Refresh((*Credentials)(nil), client, "SetCredentials").(Credentials)
+//  client.SetCredentials(credentials)
+func (container *ContainerInstance) Refresh(abstract any, target any, method string) any {
+	resolved, err := container.RefreshE(abstract, target, method)
+	if !logError(err) {
+		return nil
+	}
+
+	return resolved
+}
+
+// RefreshE - The same as Refresh, but returns an error instead of logging and returning nil
+// If target doesn't have method, or it doesn't take a single arg, an ErrInvalidTarget error is returned
+func (container *ContainerInstance) RefreshE(abstract any, target any, method string) (any, error) {
+	if target == nil {
+		return nil, newError(ErrInvalidTarget, nil, "Refresh() requires a target", nil)
+	}
+
+	setter := reflect.ValueOf(target).MethodByName(method)
+	if !setter.IsValid() {
+		return nil, newError(ErrInvalidTarget, getType(target), "Refresh() target doesn't have the method "+method, nil)
+	}
+	if setter.Type().NumIn() != 1 {
+		return nil, newError(ErrInvalidTarget, getType(target), "Refresh() method "+method+" must take a single arg", nil)
+	}
+
+	if err := container.RebindingE(abstract, func(c *ContainerInstance, instance any) error {
+		value, err := assignableValue(instance, setter.Type().In(0))
+		if err != nil {
+			return err
+		}
+
+		setter.Call([]reflect.Value{value})
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return container.MakeE(abstract)
+}
+
+// validateRebindingCallback - Check callback is a func(*ContainerInstance, T), where T is what abstractType resolves to
+// T can also be any, and the callback can return an error, which is logged
+func validateRebindingCallback(abstractType reflect.Type, callback any) (reflect.Value, error) {
+	if callback == nil {
+		return reflect.Value{}, newError(ErrInvalidBinding, abstractType, "Rebinding() requires a callback function", nil)
+	}
+
+	callbackType := getType(callback)
+
+	if callbackType.Kind() != reflect.Func ||
+		callbackType.NumIn() != 2 ||
+		callbackType.In(0) != containerInstanceType ||
+		callbackType.NumOut() > 1 ||
+		(callbackType.NumOut() == 1 && callbackType.Out(0) != errorType) {
+		return reflect.Value{}, newError(
+			ErrInvalidBinding,
+			callbackType,
+			"rebinding callback must be a func(*ContainerInstance, T)",
+			nil,
+		)
+	}
+
+	if instanceType := callbackType.In(1); instanceType != anyType && instanceType != abstractType && pointerElemType(instanceType) != abstractType {
+		return reflect.Value{}, newError(
+			ErrInvalidBinding,
+			callbackType,
+			"rebinding callback can't receive "+abstractType.String(),
+			nil,
+		)
+	}
+
+	return getVal(callback), nil
+}
+
+// rebound - Called after the binding for abstractType was replaced
+// The replaced binding's instances are evicted from our children(setBinding evicts ours), then if we
+// have any Rebinding callbacks for the type, we resolve the new instance & call them with it
+func (container *ContainerInstance) rebound(abstractType reflect.Type, replaced *Binding) {
+	container.mu.RLock()
+	children := append([]*ContainerInstance{}, container.children...)
+	callbacks := append([]reflect.Value{}, container.rebinding[abstractType]...)
+	container.mu.RUnlock()
+
+	for _, child := range children {
+		child.evict(replaced)
+	}
+
+	if len(callbacks) == 0 {
+		return
+	}
+
+	instance, err := container.MakeE(abstractType)
+	if !logError(err) {
+		return
+	}
+
+	for _, callback := range callbacks {
+		value, err := assignableValue(instance, callback.Type().In(1))
+		if !logError(err) {
+			continue
+		}
+
+		results := callback.Call([]reflect.Value{reflect.ValueOf(container), value})
+		if len(results) == 1 && !results[0].IsNil() {
+			logError(results[0].Interface().(error))
+		}
+	}
+}
+
+// evict - Remove any instances of binding cached in this container & its children
+func (container *ContainerInstance) evict(binding *Binding) {
+	container.mu.Lock()
+	delete(container.resolved, binding)
+	delete(container.failed, binding)
+	children := append([]*ContainerInstance{}, container.children...)
+	container.mu.Unlock()
+
+	for _, child := range children {
+		child.evict(binding)
+	}
+}
@@ -1,8 +1,8 @@
 package container
 
 import (
-	"log"
 	"reflect"
+	"strings"
 
 	"github.com/modern-go/reflect2"
 )
@@ -44,62 +44,163 @@ import (
 //  ContainerInstance.Bind(new(SomeConcreteService))
 //
 func (container *ContainerInstance) Bind(bindingDef ...any) bool {
+	return logError(container.BindE(bindingDef...))
+}
 
-	definition := getType(bindingDef[0])
+// BindE - The same as Bind, but returns an ErrInvalidBinding error when the binding can't be registered
+func (container *ContainerInstance) BindE(bindingDef ...any) error {
+	bindingType, binding, err := createBinding(bindingDef)
+	if err != nil {
+		return err
+	}
 
-	// Handle Function/Concrete binding
-	if len(bindingDef) == 1 {
-		if definition.Kind() == reflect.Func {
-			container.addFunctionBinding(definition, bindingDef[0])
-			return true
-		}
+	container.addBinding(bindingType, binding)
+
+	return nil
+}
+
+// createBinding - Creates the binding for Bind, from any of the definitions it accepts
+// A NamedArgs value can be included with a function binding, to pick which named bindings its args use
+// and InjectTagged values, to inject tagged bindings into its slice args
+// Returns the type we should register the binding under
+func createBinding(bindingDef []any) (reflect.Type, *Binding, error) {
+	bindingDef, namedArgs := splitNamedArgs(bindingDef)
+	bindingDef, taggedArgs := splitTaggedArgs(bindingDef)
 
-		container.addConcreteBinding(definition, bindingDef[0])
-		return true
+	if len(bindingDef) == 0 || bindingDef[0] == nil {
+		return nil, nil, newError(ErrInvalidBinding, nil, "Bind() requires at-least one binding definition", nil)
 	}
 
+	definition := getType(bindingDef[0])
+
+	var bindingType reflect.Type
+	var binding *Binding
+	var err error
+
+	switch {
+	// Handle Function/Concrete binding
+	case len(bindingDef) == 1 && definition.Kind() == reflect.Func:
+		bindingType, binding, err = newFunctionBinding(definition, bindingDef[0])
+	case len(bindingDef) == 1:
+		bindingType, binding, err = newConcreteBinding(definition)
 	// Handle Abstract -> Concrete binding
+	default:
+		bindingType, binding, err = newAbstractBinding(definition, bindingDef[1])
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
 
-	abstractType := getAbstractReturnType(definition)
-	if abstractType == nil {
-		log.Printf("Failed to get type of abstract: %s", definition.String())
-		return false
+	if err := binding.setNamedArgs(namedArgs); err != nil {
+		return nil, nil, err
 	}
 
-	concreteBindingType := getType(bindingDef[1])
-	concreteType := getConcreteReturnType(concreteBindingType)
-	if concreteType == nil {
-		log.Printf("Failed to get type of concrete: %s", concreteBindingType.String())
-		return false
+	if err := binding.setTaggedArgs(taggedArgs); err != nil {
+		return nil, nil, err
 	}
 
-	container.addBinding(abstractType, &Binding{
-		bindingType:      "Abstract",
-		abstractType:     abstractType,
-		concreteType:     concreteType,
-		resolverFunction: bindingDef[1],
-		invocable:        CreateInvocable(concreteType),
-	})
+	if err := validateInjectTags(binding.concreteType); err != nil {
+		return nil, nil, err
+	}
 
-	return true
+	return bindingType, binding, nil
 }
 
 // Singleton - Bind a "class" that should only be instantiated once when resolved
 // in the future, the initial instantiation of this type will be returned
 func (container *ContainerInstance) Singleton(singleton any, concreteResolverFunc ...any) bool {
+	return logError(container.SingletonE(singleton, concreteResolverFunc...))
+}
+
+// SingletonE - The same as Singleton, but returns an ErrInvalidBinding error when the singleton can't be registered
+func (container *ContainerInstance) SingletonE(singleton any, concreteResolverFunc ...any) error {
+	singletonType, binding, err := createSharedBinding("Singleton", singleton, concreteResolverFunc)
+	if err != nil {
+		return err
+	}
+
+	container.addSingletonBinding(singletonType, binding)
+
+	return nil
+}
+
+// Scoped - Bind a "class" that should only be instantiated once per container that resolves it
+// This is declared once, usually on the root container. Every child container created with
+// CreateChildContainer gets its own instance, which is cached in the child. Resolving it from the
+// root container gives the root's own instance.
+//
+// This is useful for request based containers, for example:
+//  Container.Scoped(NewRequestContext)
+//  requestContainer := Container.CreateChildContainer()
+//  requestContainer.Make(new(RequestContext)) // Always the same instance for this request container
+//
+// It accepts the same arguments as Singleton
+func (container *ContainerInstance) Scoped(scoped any, concreteResolverFunc ...any) bool {
+	return logError(container.ScopedE(scoped, concreteResolverFunc...))
+}
+
+// ScopedE - The same as Scoped, but returns an ErrInvalidBinding error when the binding can't be registered
+func (container *ContainerInstance) ScopedE(scoped any, concreteResolverFunc ...any) error {
+	scopedType, binding, err := createSharedBinding("Scoped", scoped, concreteResolverFunc)
+	if err != nil {
+		return err
+	}
+
+	container.addScopedBinding(scopedType, binding)
+
+	return nil
+}
+
+// createSharedBinding - Creates the binding for Singleton & Scoped, bindingType is "Singleton" or "Scoped"
+// Returns the type we should register the binding under
+func createSharedBinding(bindingType string, singleton any, concreteResolverFunc []any) (reflect.Type, *Binding, error) {
+	concreteResolverFunc, namedArgs := splitNamedArgs(concreteResolverFunc)
+	concreteResolverFunc, taggedArgs := splitTaggedArgs(concreteResolverFunc)
+
+	sharedType, binding, err := newSharedBinding(bindingType, singleton, concreteResolverFunc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := binding.setNamedArgs(namedArgs); err != nil {
+		return nil, nil, err
+	}
+
+	if err := binding.setTaggedArgs(taggedArgs); err != nil {
+		return nil, nil, err
+	}
+
+	if err := validateInjectTags(binding.concreteType); err != nil {
+		return nil, nil, err
+	}
+
+	return sharedType, binding, nil
+}
+
+// newSharedBinding - Does the work for createSharedBinding
+func newSharedBinding(bindingType string, singleton any, concreteResolverFunc []any) (reflect.Type, *Binding, error) {
+	name := strings.ToLower(bindingType)
+
+	if singleton == nil {
+		return nil, nil, newError(ErrInvalidBinding, nil, bindingType+"() requires a "+name+" definition", nil)
+	}
+
 	singletonType := getType(singleton)
 
 	// We can provide a function to singleton
-	if singletonType.Kind() == reflect.Func && concreteResolverFunc == nil {
+	if singletonType.Kind() == reflect.Func && len(concreteResolverFunc) == 0 {
 		if singletonType.NumOut() == 0 {
-			log.Printf("Please make sure your singleton function provider(%s) has at-least one return type.", singletonType.String())
-			log.Printf("Without it, we don't have a type to register this singleton under.")
-			log.Printf("Your type will not be registered in the container.")
-			return false
+			return nil, nil, newError(
+				ErrInvalidBinding,
+				singletonType,
+				name+" function provider has no return type to register the "+name+" under",
+				nil,
+			)
 		}
 
-		container.addSingletonBinding(getConcreteReturnType(singletonType.Out(0)), &Binding{
-			bindingType: "Singleton",
+		return getConcreteReturnType(singletonType.Out(0)), &Binding{
+			bindingType: bindingType,
 
 			resolverFunction:   singleton,
 			isFunctionResolver: true,
@@ -108,47 +209,43 @@ func (container *ContainerInstance) Singleton(singleton any, concreteResolverFun
 			concreteType: singletonType,
 
 			invocable: CreateInvocableFunction(singleton),
-		})
-
-		return true
+		}, nil
 	}
 
 	// We can provide a type instance directly to singleton
 	singletonConcrete := getConcreteReturnType(singletonType)
 	if singletonConcrete == nil {
-		log.Printf("Failed to get type of singleton: %s", singletonType.String())
-		log.Printf("Your singleton will not be registered in the container.")
-		return false
+		return nil, nil, newError(ErrInvalidBinding, singletonType, "failed to get type of "+name, nil)
 	}
 
 	// If we don't have a resolver func, we're just defining the singleton type...
-	if concreteResolverFunc == nil {
-		container.addSingletonBinding(singletonConcrete, &Binding{
-			bindingType: "Singleton",
+	if len(concreteResolverFunc) == 0 {
+		invocable := CreateInvocable(singletonConcrete)
+		if invocable == nil {
+			return nil, nil, newError(ErrInvalidBinding, singletonConcrete, name+" is not a struct or function", nil)
+		}
+
+		return singletonConcrete, &Binding{
+			bindingType: bindingType,
 
 			isFunctionResolver: false,
 
 			abstractType: singletonConcrete,
 			concreteType: singletonConcrete,
-			invocable:    CreateInvocable(singletonConcrete),
-		})
-		return true
+			invocable:    invocable,
+		}, nil
 	}
 
 	// We can provide a type instance to singleton but use
 	// concreteResolverFunc to resolve the initial singleton instance
 
 	resolverFunc := concreteResolverFunc[0]
-	resolverFuncType := getType(resolverFunc)
-
-	if resolverFuncType.Kind() != reflect.Func {
-		log.Printf("Trying to register singleton(%s) -> resolver binding but resolver is not a function", singletonType.String())
-		log.Printf("Your singleton will not be registered in the container.")
-		return false
+	if resolverFunc == nil || getType(resolverFunc).Kind() != reflect.Func {
+		return nil, nil, newError(ErrInvalidBinding, singletonType, name+" resolver is not a function", nil)
 	}
 
-	container.addSingletonBinding(singletonConcrete, &Binding{
-		bindingType: "Singleton",
+	binding := &Binding{
+		bindingType: bindingType,
 
 		isFunctionResolver: true,
 		resolverFunction:   resolverFunc,
@@ -156,38 +253,36 @@ func (container *ContainerInstance) Singleton(singleton any, concreteResolverFun
 		abstractType: singletonConcrete,
 		concreteType: singletonConcrete,
 		invocable:    CreateInvocableFunction(resolverFunc),
-	})
+	}
 
-	return true
+	if err := checkBindingGives(binding, resolverFunc, singletonConcrete); err != nil {
+		return nil, nil, err
+	}
+
+	return singletonConcrete, binding, nil
 }
 
 // Instance - This is similar to Singleton, except with Singleton we provide a type to instantiate
 // With instance, we provide an already instantiated value to the container
 func (container *ContainerInstance) Instance(instance any) bool {
+	return logError(container.InstanceE(instance))
+}
+
+// InstanceE - The same as Instance, but returns an ErrInvalidBinding error when the instance can't be registered
+func (container *ContainerInstance) InstanceE(instance any) error {
+	if instance == nil {
+		return newError(ErrInvalidBinding, nil, "Instance() requires a non-nil instance", nil)
+	}
+
 	instanceType := getType(instance)
 
 	singletonConcrete := getConcreteReturnType(instanceType)
 
 	if singletonConcrete == nil {
-		log.Printf("Failed to get type of instance singleton: %s", instanceType.String())
-		log.Printf("Your instance will not be registered in the container.")
-		return false
+		return newError(ErrInvalidBinding, instanceType, "failed to get type of instance singleton", nil)
 	}
 
-	container.addSingletonBinding(singletonConcrete, &Binding{
-		bindingType: "Singleton",
-
-		isFunctionResolver: false,
-
-		abstractType: singletonConcrete,
-		concreteType: singletonConcrete,
-		invocable:    CreateInvocable(singletonConcrete),
-	})
-
-	// Our instance is already instantiated, we'll pass it straight to resolved
-	container.resolved[singletonConcrete] = instance
-
-	return true
+	return container.addInstanceBinding(singletonConcrete, singletonConcrete, instance)
 }
 
 // IsBound - Check if the provided value type exists in our container
@@ -204,14 +299,39 @@ func (container *ContainerInstance) IsBound(binding any) bool {
 // For example:
 //  service := ContainerInstance.Make((*ServiceAbstract)(nil))
 func (container *ContainerInstance) Make(abstract any, parameters ...any) any {
+	resolved, err := container.MakeE(abstract, parameters...)
+	if !logError(err) {
+		return nil
+	}
+
+	return resolved
+}
+
+// MakeE - The same as Make, but returns an error instead of logging and returning nil
+// For example:
+//  service, err := ContainerInstance.MakeE((*ServiceAbstract)(nil))
+//  if errors.Is(err, container.ErrNotBound) { ... }
+func (container *ContainerInstance) MakeE(abstract any, parameters ...any) (any, error) {
+	if abstract == nil {
+		return nil, newError(ErrInvalidTarget, nil, "cannot make a nil abstract", nil)
+	}
+
 	binding := container.getBindingType(abstract)
 
 	if binding == nil {
-		log.Printf("Failed to resolve binding for abstract type %s", reflect.TypeOf(abstract).String())
-		return nil
+		abstractType := getType(abstract)
+		if resolved, ok, err := container.resolveMany(newResolution(), topLevelSite, pointerElemType(abstractType)); ok {
+			return resolved, err
+		}
+
+		if interfaceType := getAbstractReturnType(abstractType); interfaceType != nil {
+			abstractType = interfaceType
+		}
+
+		return nil, newError(ErrNotBound, abstractType, "", nil)
 	}
 
-	return container.makeFromBinding(binding, parameters...)
+	return container.makeFromBinding(newResolution(), topLevelSite, binding, parameters...)
 }
 
 // MakeTo - Try to make a new instance of the provided value and assign it to your arg
@@ -219,37 +339,67 @@ func (container *ContainerInstance) Make(abstract any, parameters ...any) any {
 //  var service ServiceAbstract
 //  ContainerInstance.MakeTo(&service)
 func (container *ContainerInstance) MakeTo(makeTo any, parameters ...any) {
+	logError(container.MakeToE(makeTo, parameters...))
+}
+
+// MakeToE - The same as MakeTo, but returns an error instead of logging
+// For example:
+//  var service ServiceAbstract
+//  err := ContainerInstance.MakeToE(&service)
+func (container *ContainerInstance) MakeToE(makeTo any, parameters ...any) error {
+	if makeTo == nil {
+		return newError(ErrInvalidTarget, nil, "the makeTo arg must be a pointer to your receiving var", nil)
+	}
+
 	makeToVal := getVal(makeTo)
 
-	if makeToVal.Kind() != reflect.Pointer {
-		log.Printf("Call to ContainerInstance.MakeTo(), the makeTo arg must be a pointer to your receiving var. Ex; var service ServiceAbstract; ContainerInstance.MakeTo(&service)...")
-		return
+	if makeToVal.Kind() != reflect.Pointer || makeToVal.IsNil() {
+		return newError(
+			ErrInvalidTarget,
+			makeToVal.Type(),
+			"the makeTo arg must be a pointer to your receiving var. Ex; var service ServiceAbstract; ContainerInstance.MakeTo(&service)",
+			nil,
+		)
 	}
 
 	makeToElem := makeToVal.Elem()
 	makeToType := makeToElem.Type()
 	if !makeToElem.CanSet() {
-		log.Printf("Call to ContainerInstance.MakeTo(), the makeTo arg cannot be set?")
-		return
+		return newError(ErrInvalidTarget, makeToType, "the makeTo arg cannot be set", nil)
 	}
 
-	resolved := container.Make(makeToType, parameters...)
+	resolved, err := container.MakeE(makeToType, parameters...)
+	if err != nil {
+		return err
+	}
 	if resolved == nil {
-		return
+		return nil
 	}
 
 	resolvedValue := reflect.ValueOf(resolved)
 
-	if makeToVal.Kind() == reflect.Ptr && resolvedValue.Kind() != reflect.Ptr {
+	// Slices from BindMany can be assigned as they are
+	if makeToVal.Kind() == reflect.Ptr && resolvedValue.Kind() != reflect.Ptr && resolvedValue.Kind() != reflect.Slice {
 		reflect2.TypeOf(makeTo).UnsafeSet(
 			makeToVal.UnsafePointer(),
 			reflect2.PtrOf(resolved),
 		)
-		return
+		return nil
 	}
 
 	// ptr := reflect.NewAt(makeToValIndirect.Type(), unsafe.Pointer(makeToValIndirect.UnsafeAddr())).Elem()
 	// ptr.Set(resolvedValue.Addr())
 
+	if !resolvedValue.Type().AssignableTo(makeToType) {
+		return newError(
+			ErrInvalidTarget,
+			makeToType,
+			"resolved value of type "+resolvedValue.Type().String()+" is not assignable to the makeTo arg",
+			nil,
+		)
+	}
+
 	makeToElem.Set(resolvedValue)
+
+	return nil
 }
@@ -1,8 +1,7 @@
 package container
 
 import (
-	"errors"
-	"log"
+	"fmt"
 	"reflect"
 	"unsafe"
 )
@@ -22,27 +21,48 @@ import (
 //
 // Type bindings:
 // - Instantiate the type, return it
-func (container *ContainerInstance) resolve(binding *Binding, parameters ...any) any {
-	if binding.isSingleton {
-		return container.resolveSingleton(binding, parameters...)
+//
+// Any extenders & resolving callbacks for the binding are applied to the instance we create
+// Transients we create which implement io.Closer or Disposable are only tracked for Close with Config.DisposeTransients
+func (container *ContainerInstance) resolve(res *resolution, binding *Binding, parameters ...any) (any, error) {
+	if binding.isSingleton || binding.isScoped {
+		return container.resolveSingleton(res, binding, parameters...)
 	}
 
+	var instance any
+	var err error
+
 	if binding.isFunctionResolver {
-		return container.resolveFromFunctionResolver(binding, parameters...)
+		instance, err = container.resolveFromFunctionResolver(res, binding, parameters...)
+	} else {
+		instance, err = binding.invocable.instantiateWith(container, res)
+	}
+
+	if err == nil {
+		instance, err = container.finishResolving(binding, instance)
 	}
 
-	return binding.invocable.InstantiateWith(container)
+	if err == nil && instance != nil && container.Config.DisposeTransients {
+		container.mu.Lock()
+		container.trackDisposable(instance, nil)
+		container.mu.Unlock()
+
+		container.attachToParent()
+	}
+
+	return instance, err
 }
 
 // resolveStructFields - Attempt to resolve all the fields from the container, for the specified struct
-func (container *ContainerInstance) resolveStructFields(instanceType reflect.Type, instance reflect.Value) reflect.Value {
+// Each field's `inject` tag decides how it's resolved, see injectTag
+func (container *ContainerInstance) resolveStructFields(res *resolution, instanceType reflect.Type, instance reflect.Value) (reflect.Value, error) {
 	if instanceType == nil {
-		panic(errors.New("container: invalid structure"))
+		return instance, newError(ErrInvalidTarget, nil, "invalid structure", nil)
 	}
 
 	structType := indirectType(instanceType)
 	if structType.Kind() != reflect.Struct {
-		panic(errors.New("container: invalid structure"))
+		return instance, newError(ErrInvalidTarget, instanceType, "invalid structure", nil)
 	}
 
 	structValue := instance
@@ -54,33 +74,100 @@ func (container *ContainerInstance) resolveStructFields(instanceType reflect.Typ
 		field := structValue.Field(i)
 		fieldType := structType.Field(i)
 
-		if container.Config.OnlyInjectStructFieldsWithInjectTag {
-			if tag, ok := fieldType.Tag.Lookup("inject"); ok {
-				print("Inject tag is : " + tag)
-			}
+		tag, hasTag, err := parseInjectTag(fieldType)
+		if err != nil {
+			return instance, err
+		}
+
+		if tag.skip || (!hasTag && container.Config.OnlyInjectStructFieldsWithInjectTag) {
 			continue
 		}
 
-		fieldBinding := container.getBindingType(field.Type())
-		if fieldBinding != nil {
-			resolved := container.makeFromBinding(fieldBinding)
-			if resolved != nil {
-				ptr := reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
-				ptr.Set(reflect.ValueOf(resolved))
-			}
+		resolved, ok, err := container.resolveStructField(res, structType, fieldType, tag, hasTag)
+		if err != nil {
+			return instance, err
+		}
+		if ok {
+			ptr := reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+			ptr.Set(resolved)
+		}
+	}
+
+	return instance, nil
+}
+
+// resolveStructField - Resolve the value for a single struct field, using its inject tag
+// Fields with a tag are required unless they're optional, fields without one are only injected when their type is bound
+// Contextual bindings for the struct, or the binding being resolved, win over the field type's own binding
+// Returns false when the field should be left as it is
+func (container *ContainerInstance) resolveStructField(res *resolution, structType reflect.Type, field reflect.StructField, tag injectTag, hasTag bool) (reflect.Value, bool, error) {
+	site := fieldSite(field.Name)
+	consumers := []reflect.Type{structType, res.current()}
+	required := hasTag && !tag.optional
+
+	if tag.tagged != "" {
+		resolved, err := container.resolveTaggedSlice(res, site, tag.tagged, field.Type)
+		return resolved, err == nil, err
+	}
+
+	if tag.name != "" {
+		if !required && !container.IsBoundNamed(tag.name, field.Type) {
+			return reflect.Value{}, false, nil
+		}
+
+		resolved, err := container.makeNamed(res, site, tag.name, field.Type)
+		if err != nil || resolved == nil {
+			return reflect.Value{}, false, err
+		}
+
+		value, err := assignableValue(resolved, field.Type)
+		return value, err == nil, err
+	}
+
+	// Lazy[T] & provider funcs are resolved when they're used, not now
+	if deferred, ok := container.resolveDeferred(consumers, field.Type); ok {
+		return deferred, true, nil
+	}
+
+	resolved, bound, err := container.resolveDependency(res, site, consumers, field.Type)
+	if !bound {
+		if !required {
+			return reflect.Value{}, false, nil
 		}
+
+		step := site.step(field.Type, nil)
+		return reflect.Value{}, false, res.fail(&step, newError(ErrNotBound, field.Type, "", nil))
+	}
+	if err != nil || resolved == nil {
+		return reflect.Value{}, false, err
 	}
 
-	return instance
+	value, err := assignableValue(resolved, field.Type)
+	return value, err == nil, err
 }
 
 type FuncArgResolverInterceptor = func(index int, argType reflect.Type, typeZeroVal reflect.Value) (reflect.Value, bool)
 
+// ResolveFunctionArgsWithInterceptor - The same as ResolveFunctionArgs, but the interceptor is called for every arg first,
+// if it returns true, the value it returned will be used for that arg
 func (container *ContainerInstance) ResolveFunctionArgsWithInterceptor(function reflect.Value, interceptor FuncArgResolverInterceptor, parameters ...any) []reflect.Value {
+	args, err := container.resolveFunctionArgs(newResolution(), function, interceptor, parameters...)
+	logError(err)
+
+	return args
+}
+
+// resolveFunctionArgs - Does the work for ResolveFunctionArgsWithInterceptor
+// Any arg that we can't resolve is assigned a zero value, and the first failure is returned as the error
+// NamedArgs in parameters aren't used as an arg, they pick the named bindings to resolve args from
+func (container *ContainerInstance) resolveFunctionArgs(res *resolution, function reflect.Value, interceptor FuncArgResolverInterceptor, parameters ...any) ([]reflect.Value, error) {
 	inArgCount := 0
 
+	parameters, namedArgs := splitNamedArgs(parameters)
+	parameters, taggedArgs := splitTaggedArgs(parameters)
+
 	if !function.IsValid() || function.IsZero() {
-		return []reflect.Value{}
+		return []reflect.Value{}, nil
 	}
 
 	functionType := getType(function)
@@ -115,8 +202,11 @@ func (container *ContainerInstance) ResolveFunctionArgsWithInterceptor(function
 
 		// We can provide a function as an "interceptor" instead...
 		if parametersType.Kind() == reflect.Array || parametersType.Kind() == reflect.Slice {
-			for i := 0; i < len(parameters); i++ {
+			for i := 0; i < len(parameters) && i < inArgCount; i++ {
 				paramVal := reflect.ValueOf(parameters[i])
+				if !paramVal.IsValid() {
+					continue
+				}
 
 				// We'll only assign the param from the provided list, if the type matches?
 				inArg := inArgTypes[i]
@@ -127,12 +217,14 @@ func (container *ContainerInstance) ResolveFunctionArgsWithInterceptor(function
 
 			// If our provided parameters fulfils all the function args, let's just early return
 			if assignedCount >= inArgCount {
-				return args
+				return args, nil
 			}
 		}
 
 	}
 
+	var resolveErr error
+
 	// Now we'll try to resolve any other types from the container
 	for i := 0; i < inArgCount; i++ {
 		interceptedVal, didIntercept := interceptor(i, inArgTypes[i], args[i])
@@ -149,15 +241,21 @@ func (container *ContainerInstance) ResolveFunctionArgsWithInterceptor(function
 		// Now we'll attempt to resolve in inArg from the container...
 		// If it can be resolved/exists, we'll provide the value
 		// Otherwise, we'll create a new zero type of the arg
-		resolved, didResolve := container.resolveFunctionArg(inArgTypes[i])
-		if !didResolve {
-			log.Printf("Assigning empty arg for arg(%d) on resolving type %s", i, functionType.Out(0).String())
+		var resolved reflect.Value
+		var err error
+		if tag, ok := taggedArgs[inArgTypes[i]]; ok {
+			resolved, err = container.resolveTaggedSlice(res, argSite(i), tag, inArgTypes[i])
+		} else {
+			resolved, err = container.resolveFunctionArg(res, i, inArgTypes[i], namedArgs[i])
+		}
+		if err != nil && resolveErr == nil {
+			resolveErr = err
 		}
 
 		assignArg(i, resolved)
 	}
 
-	return args
+	return args, resolveErr
 
 }
 
@@ -167,73 +265,167 @@ func (container *ContainerInstance) ResolveFunctionArgsWithInterceptor(function
 // Then we'll look at the function args, and if we assigned a value from the parameters already
 // it will use that, otherwise we'll look the type up in the container and resolve it
 func (container *ContainerInstance) ResolveFunctionArgs(function reflect.Value, parameters ...any) []reflect.Value {
-	interceptor := func(index int, argType reflect.Type, typeZeroVal reflect.Value) (reflect.Value, bool) {
-		return typeZeroVal, false
-	}
-	return container.ResolveFunctionArgsWithInterceptor(function, interceptor, parameters...)
+	args, err := container.resolveFunctionArgs(newResolution(), function, noArgInterceptor, parameters...)
+	logError(err)
+
+	return args
+}
+
+// noArgInterceptor - Used when resolving function args without an interceptor
+func noArgInterceptor(index int, argType reflect.Type, typeZeroVal reflect.Value) (reflect.Value, bool) {
+	return typeZeroVal, false
 }
 
 // resolveFunctionArg - Used in ResolveFunctionArgs, we pass an arg type and attempt to
 // resolve it from the container, if the type doesn't exist in the container
-// we'll return a zero value version of the type
-func (container *ContainerInstance) resolveFunctionArg(arg reflect.Type) (reflect.Value, bool) {
-	argBinding := container.getBindingType(arg)
-	if argBinding == nil {
-		return reflect.New(arg), false
+// we'll return a zero value version of the type and the reason it couldn't be resolved
+// When name isn't empty, the arg is resolved from the binding registered under that name
+// Otherwise, a contextual binding for the binding being resolved wins over the arg type's own binding
+func (container *ContainerInstance) resolveFunctionArg(res *resolution, index int, arg reflect.Type, name string) (reflect.Value, error) {
+	var resolved any
+	var err error
+
+	if name != "" {
+		resolved, err = container.makeNamed(res, argSite(index), name, arg)
+	} else {
+		consumers := []reflect.Type{res.current()}
+
+		// Lazy[T] & provider funcs are resolved when they're used, not now
+		if deferred, ok := container.resolveDeferred(consumers, arg); ok {
+			return deferred, nil
+		}
+
+		var bound bool
+		resolved, bound, err = container.resolveDependency(res, argSite(index), consumers, arg)
+		if !bound {
+			step := argSite(index).step(arg, nil)
+			return reflect.Zero(arg), res.fail(&step, newError(ErrNotBound, arg, "", nil))
+		}
 	}
 
-	resolved := container.Make(argBinding)
+	if err != nil {
+		return reflect.Zero(arg), err
+	}
 	if resolved == nil {
-		return reflect.New(arg), false
+		return reflect.Zero(arg), nil
 	}
 
-	return reflect.ValueOf(resolved), true
+	return reflect.ValueOf(resolved), nil
 }
 
 // resolveFromFunctionResolver - Call the bound concrete function and provide any args,
 // from parameters & the container. If our bound function returns an error for the second
-// return value, and there is an error, our code will panic.
-func (container *ContainerInstance) resolveFromFunctionResolver(binding *Binding, parameters ...any) any {
+// return value, and there is an error, we'll return it wrapped in ErrConstructorFailed
+func (container *ContainerInstance) resolveFromFunctionResolver(res *resolution, binding *Binding, parameters ...any) (any, error) {
+
+	// NamedArgs & InjectTagged passed to Make are added after the bindings, so they take priority
+	if binding.namedArgs != nil || binding.taggedArgs != nil {
+		bound := make([]any, 0, len(binding.taggedArgs)+1+len(parameters))
+		if binding.namedArgs != nil {
+			bound = append(bound, binding.namedArgs)
+		}
+		for _, taggedArg := range binding.taggedArgs {
+			bound = append(bound, taggedArg)
+		}
+		parameters = append(bound, parameters...)
+	}
 
-	instanceReturnValues := binding.invocable.CallMethodWith(container, parameters...)
+	instanceReturnValues, err := binding.invocable.callMethodWith(container, res, parameters...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(instanceReturnValues) == 0 {
+		return nil, newError(ErrConstructorFailed, binding.abstractType, "resolver function returned no values", nil)
+	}
 
 	// If we have two return values... it's possible arg 1 is our implementation, arg 2 is an error?
-	// If this is the case, we'll panic, idk what to do here.
 	if len(instanceReturnValues) >= 2 {
 		instance := instanceReturnValues[0]
 
 		if err, ok := instanceReturnValues[1].Interface().(error); ok {
-			panic(err.Error())
+			return nil, newError(ErrConstructorFailed, binding.abstractType, "", err)
 		}
 
-		return instance.Interface()
+		return binding.checkResolved(instance.Interface())
 	}
 
-	return instanceReturnValues[0].Interface()
+	return binding.checkResolved(instanceReturnValues[0].Interface())
+}
+
+// checkResolved - Check what our resolver function returned can be used as our abstract
+// A function returning an interface can return anything, so an interface abstract is only known to be
+// implemented once we have the instance
+func (binding *Binding) checkResolved(instance any) (any, error) {
+	if instance == nil || binding.abstractType.Kind() != reflect.Interface {
+		return instance, nil
+	}
+
+	if instanceType := reflect.TypeOf(instance); !instanceType.Implements(binding.abstractType) {
+		return nil, newError(
+			ErrInvalidBinding,
+			binding.abstractType,
+			"resolver function returned "+instanceType.String()+", which doesn't implement "+binding.abstractType.String(),
+			nil,
+		)
+	}
+
+	return instance, nil
 }
 
 // resolveSingleton - Works similarly to resolve, except we're doing the function/type binding parts
 // If our instance already exists in container.resolved, we'll return it from there
-func (container *ContainerInstance) resolveSingleton(binding *Binding, parameters ...any) any {
-	if instance, ok := container.resolved[binding.concreteType]; ok {
-		return instance
+// This is also used for scoped bindings, they're just singletons cached in the container resolving them
+//
+// The singleton is only ever constructed once, if another goroutine is already constructing it
+// we'll wait for it to finish and return the same instance(or error)
+func (container *ContainerInstance) resolveSingleton(res *resolution, binding *Binding, parameters ...any) (any, error) {
+	container.mu.Lock()
+	if instance, ok := container.resolved[binding]; ok {
+		container.mu.Unlock()
+		return instance, nil
 	}
+	if err, ok := container.failed[binding]; ok {
+		container.mu.Unlock()
+		return nil, err
+	}
+	if pending, ok := container.pending[binding]; ok {
+		container.mu.Unlock()
+		return container.waitForSingleton(res, pending)
+	}
+
+	pending := newPendingSingleton(res)
+	container.pending[binding] = pending
+	container.mu.Unlock()
+
+	// If the constructor panics, we still need to release anyone waiting on it
+	defer func() {
+		if r := recover(); r != nil {
+			container.finishSingleton(binding, pending, nil, newError(
+				ErrConstructorFailed,
+				res.current(),
+				fmt.Sprintf("singleton constructor panicked: %v", r),
+				nil,
+			))
+			panic(r)
+		}
+	}()
 
 	var resolvedInstance any
+	var err error
 
 	if binding.isFunctionResolver {
-		resolvedInstance = container.resolveFromFunctionResolver(binding, parameters...)
-		if resolvedInstance == nil {
-			return nil
-		}
+		resolvedInstance, err = container.resolveFromFunctionResolver(res, binding, parameters...)
 	} else {
-		resolvedInstance = binding.invocable.InstantiateWith(container)
-		if resolvedInstance == nil {
-			return nil
-		}
+		resolvedInstance, err = binding.invocable.instantiateWith(container, res)
+	}
+
+	// The extended instance is the one we cache, resolving callbacks are only called when it's created
+	if err == nil {
+		resolvedInstance, err = container.finishResolving(binding, resolvedInstance)
 	}
 
-	container.resolved[binding.concreteType] = resolvedInstance
+	container.finishSingleton(binding, pending, resolvedInstance, err)
 
-	return resolvedInstance
+	return resolvedInstance, err
 }
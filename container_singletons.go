@@ -0,0 +1,165 @@
+package container
+
+import (
+	"bytes"
+	"log"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// singletonWaitWarning - When SingletonWaitTimeout isn't set, we'll log a warning
+// if we've been waiting on another goroutine's singleton construction for this long
+const singletonWaitWarning = 5 * time.Second
+
+// pendingSingleton - A singleton which is currently being constructed
+// Anyone else that resolves the singleton waits on done, rather than constructing it again
+type pendingSingleton struct {
+	// The resolution which is constructing the singleton
+	owner *resolution
+	// The goroutine running the constructor, anything else it resolves while it's running happens on its stack
+	goroutine uint64
+	// The type the singleton was resolved with, used to describe deadlocks
+	bindingType reflect.Type
+
+	done     chan struct{}
+	instance any
+	err      error
+}
+
+// singletonWaits - Guards resolution.waitingOn for every resolution, so we can safely
+// follow the chain of "resolution -> singleton it's waiting on -> resolution constructing it"
+var singletonWaits sync.Mutex
+
+func newPendingSingleton(owner *resolution) *pendingSingleton {
+	return &pendingSingleton{
+		owner:       owner,
+		goroutine:   currentGoroutine(),
+		bindingType: owner.current(),
+		done:        make(chan struct{}),
+	}
+}
+
+// finishSingleton - Store the result of a singleton construction & release anyone waiting on it
+func (container *ContainerInstance) finishSingleton(binding *Binding, pending *pendingSingleton, instance any, err error) {
+	container.mu.Lock()
+	delete(container.pending, binding)
+
+	if err == nil && instance != nil {
+		container.resolved[binding] = instance
+		container.trackDisposable(instance, nil)
+	}
+	if err != nil && container.Config.SingletonErrorPolicy == MemoizeSingletonErrors {
+		container.failed[binding] = err
+	}
+	container.mu.Unlock()
+
+	container.attachToParent()
+
+	pending.instance = instance
+	pending.err = err
+	close(pending.done)
+}
+
+// waitForSingleton - Wait for another resolution to finish constructing the singleton
+// If the other resolution is (directly or through others) waiting on a singleton that we're
+// constructing, neither would ever finish, so we return ErrDeadlock instead of waiting
+func (container *ContainerInstance) waitForSingleton(res *resolution, pending *pendingSingleton) (any, error) {
+	if err := res.startWaiting(pending); err != nil {
+		return nil, err
+	}
+	defer res.stopWaiting()
+
+	timeout := container.Config.SingletonWaitTimeout
+
+	wait := timeout
+	if wait <= 0 {
+		wait = singletonWaitWarning
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-pending.done:
+		return pending.instance, pending.err
+	case <-timer.C:
+	}
+
+	if timeout > 0 {
+		return nil, newError(
+			ErrDeadlock,
+			pending.bindingType,
+			"timed out after "+timeout.String()+" waiting for another goroutine to construct the singleton",
+			nil,
+		)
+	}
+
+	log.Printf(
+		"Still waiting for singleton %s to be constructed by another goroutine after %s, this may be a deadlock. Set ContainerConfig.SingletonWaitTimeout to fail instead of waiting forever.",
+		describeType(pending.bindingType),
+		wait.String(),
+	)
+
+	<-pending.done
+
+	return pending.instance, pending.err
+}
+
+// currentGoroutine - The id of the goroutine we're running on, parsed from the "goroutine 18 [running]:" stack header
+// Go doesn't expose it otherwise, it's only used to tell if a wait is on the stack of the goroutine it waits for
+func currentGoroutine() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+
+	if i := bytes.IndexByte(buf, ' '); i >= 0 {
+		buf = buf[:i]
+	}
+
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+
+	return id
+}
+
+// startWaiting - Mark res as waiting on pending, unless that would deadlock
+func (res *resolution) startWaiting(pending *pendingSingleton) error {
+	singletonWaits.Lock()
+	defer singletonWaits.Unlock()
+
+	chain := []string{describeType(pending.bindingType)}
+	goroutine := currentGoroutine()
+
+	// The constructor's goroutine can't finish while it's waiting, so waiting from its own stack, via a
+	// Lazy/provider func or a Make in its constructor, is a deadlock. Other goroutines can just wait for it
+	for next := pending; next != nil; next = next.owner.waitingOn {
+		if next.owner == res || next.goroutine == goroutine {
+			chain = append([]string{describeType(next.bindingType)}, chain...)
+
+			return newError(
+				ErrDeadlock,
+				pending.bindingType,
+				"singleton construction waits on itself: "+strings.Join(chain, " -> "),
+				nil,
+			)
+		}
+
+		if next.owner.waitingOn != nil {
+			chain = append(chain, describeType(next.owner.waitingOn.bindingType))
+		}
+	}
+
+	res.waitingOn = pending
+
+	return nil
+}
+
+func (res *resolution) stopWaiting() {
+	singletonWaits.Lock()
+	defer singletonWaits.Unlock()
+
+	res.waitingOn = nil
+}
@@ -0,0 +1,229 @@
+package container
+
+import (
+	"reflect"
+	"sync"
+)
+
+// swap - A binding replaced by Swap, with everything we need to put it back
+type swap struct {
+	// The binding we swapped in
+	binding *Binding
+
+	// The binding that was registered before, nil if the type wasn't bound in this container
+	previous *Binding
+
+	// The instance cached for previous when we swapped, if it had one
+	previousInstance    any
+	hasPreviousInstance bool
+
+	// What concretes held for the fake's concrete type before we swapped
+	previousConcrete    reflect.Type
+	hasPreviousConcrete bool
+}
+
+// Swap - Replace the binding for abstract with fake, until the returned restore func is called
+// This is meant for tests, where real services are swapped for fakes, the previous binding & its
+// cached instance are kept, so restoring doesn't re-register anything
+//
+// fake is either an instance, which is used as is, or a function returning the abstract, which is
+// bound like Bind would. Extenders aren't applied to fake instances & Rebinding callbacks aren't called.
+//
+// For example:
+//  t.Cleanup(Container.Swap((*Mailer)(nil), &FakeMailer{}))
+//
+// Swaps can be nested, each restore puts back what was bound before its Swap, even if they're
+// called out of order
+func (container *ContainerInstance) Swap(abstract any, fake any) (restore func()) {
+	restore, err := container.SwapE(abstract, fake)
+	if !logError(err) {
+		return func() {}
+	}
+
+	return restore
+}
+
+// SwapE - The same as Swap, but returns an ErrInvalidBinding error when fake can't be swapped in
+func (container *ContainerInstance) SwapE(abstract any, fake any) (func(), error) {
+	if abstract == nil {
+		return nil, newError(ErrInvalidBinding, nil, "Swap() requires an abstract", nil)
+	}
+
+	abstractType := lookupType(getType(abstract))
+	if abstractType == nil {
+		return nil, newError(ErrInvalidBinding, getType(abstract), "failed to get type of abstract", nil)
+	}
+
+	binding, instance, err := newFakeBinding(abstractType, fake)
+	if err != nil {
+		return nil, err
+	}
+
+	container.mu.Lock()
+
+	s := &swap{
+		binding:  binding,
+		previous: container.bindings[abstractType],
+	}
+	if s.previous != nil {
+		s.previousInstance, s.hasPreviousInstance = container.resolved[s.previous]
+	}
+	s.previousConcrete, s.hasPreviousConcrete = container.concretes[binding.concreteType]
+
+	binding.registeredType = abstractType
+	container.bindings[abstractType] = binding
+	container.concretes[binding.concreteType] = abstractType
+	if binding.isInstance {
+		container.resolved[binding] = instance
+	}
+
+	container.swaps[abstractType] = append(container.swaps[abstractType], s)
+	container.mu.Unlock()
+
+	// Our children may have scoped instances of the binding we replaced, they'd keep using them instead of the fake
+	if s.previous != nil {
+		container.evictFromChildren(s.previous)
+	}
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			container.restoreSwap(abstractType, s)
+		})
+	}, nil
+}
+
+// newFakeBinding - Create the binding for a Swap fake, returns the instance to cache for it, if it's one
+func newFakeBinding(abstractType reflect.Type, fake any) (*Binding, any, error) {
+	if fake == nil {
+		return nil, nil, newError(ErrInvalidBinding, abstractType, "Swap() requires a fake", nil)
+	}
+
+	fakeType := getType(fake)
+
+	if fakeType.Kind() == reflect.Func {
+		_, binding, err := newFunctionBinding(fakeType, fake)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := checkBindingGives(binding, fake, abstractType); err != nil {
+			return nil, nil, err
+		}
+
+		return binding, nil, nil
+	}
+
+	if err := checkGives(fakeType, abstractType, true); err != nil {
+		return nil, nil, err
+	}
+
+	concreteType := getConcreteReturnType(fakeType)
+
+	return &Binding{
+		bindingType: "Singleton",
+
+		isSingleton: true,
+		isInstance:  true,
+
+		abstractType: abstractType,
+		concreteType: concreteType,
+		invocable:    CreateInvocable(concreteType),
+	}, fake, nil
+}
+
+// restoreSwap - Undo the swap, when it's the latest swap for the type, its previous binding is
+// registered again. Otherwise, the swap made after it will restore our previous binding instead
+func (container *ContainerInstance) restoreSwap(abstractType reflect.Type, s *swap) {
+	if container.removeSwap(abstractType, s) {
+		container.evictFromChildren(s.binding)
+	}
+}
+
+// removeSwap - Does the work for restoreSwap, returns true if the fake was the binding in use, so it's been replaced
+func (container *ContainerInstance) removeSwap(abstractType reflect.Type, s *swap) bool {
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
+	swaps := container.swaps[abstractType]
+
+	index := -1
+	for i, swapped := range swaps {
+		if swapped == s {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return false
+	}
+
+	latest := index == len(swaps)-1
+
+	if !latest {
+		next := swaps[index+1]
+		next.previous = s.previous
+		next.previousInstance, next.hasPreviousInstance = s.previousInstance, s.hasPreviousInstance
+
+		if next.binding.concreteType == s.binding.concreteType {
+			next.previousConcrete, next.hasPreviousConcrete = s.previousConcrete, s.hasPreviousConcrete
+		} else {
+			container.unswapConcrete(abstractType, s)
+		}
+	} else {
+		container.unswap(abstractType, s)
+	}
+
+	swaps = append(swaps[:index:index], swaps[index+1:]...)
+	if len(swaps) == 0 {
+		delete(container.swaps, abstractType)
+	} else {
+		container.swaps[abstractType] = swaps
+	}
+
+	return latest
+}
+
+// evictFromChildren - Remove the instances of binding cached in our children, ours are handled by the swap itself
+func (container *ContainerInstance) evictFromChildren(binding *Binding) {
+	container.mu.RLock()
+	children := append([]*ContainerInstance{}, container.children...)
+	container.mu.RUnlock()
+
+	for _, child := range children {
+		for b := binding; b != nil; b = b.fallback {
+			child.evict(b)
+		}
+	}
+}
+
+// unswap - Put the binding from before the swap back, container.mu must be held by the caller
+func (container *ContainerInstance) unswap(abstractType reflect.Type, s *swap) {
+	delete(container.resolved, s.binding)
+	delete(container.failed, s.binding)
+	container.unswapConcrete(abstractType, s)
+
+	if s.previous == nil {
+		delete(container.bindings, abstractType)
+		return
+	}
+
+	container.bindings[abstractType] = s.previous
+	if s.hasPreviousInstance {
+		container.resolved[s.previous] = s.previousInstance
+	}
+}
+
+// unswapConcrete - Put back what concretes held for the fake's concrete type, container.mu must be held by the caller
+func (container *ContainerInstance) unswapConcrete(abstractType reflect.Type, s *swap) {
+	if container.concretes[s.binding.concreteType] != abstractType {
+		return
+	}
+
+	if s.hasPreviousConcrete {
+		container.concretes[s.binding.concreteType] = s.previousConcrete
+	} else {
+		delete(container.concretes, s.binding.concreteType)
+	}
+}
@@ -4,6 +4,19 @@ import (
 	"reflect"
 )
 
+// TaggedArg - Injects the bindings tagged with a tag into a function's []T arg, create it with InjectTagged
+// It can be passed anywhere function args are resolved, Bind, Singleton, Scoped, Call & Make, with the rest
+// of the definition/parameters, like NamedArgs
+//
+// For example:
+//  Container.Tag("StatServices", new(PageViewsStatService), new(UserPostViewsStatService))
+//  Container.Bind(NewDashboard, container.InjectTagged[StatService]("StatServices"))
+//  Container.Call(func(stats []StatService) {}, container.InjectTagged[StatService]("StatServices"))
+type TaggedArg struct {
+	tag       string
+	sliceType reflect.Type
+}
+
 // Tag - When we've bound to the container, we can then tag the abstracts with a string
 // This is useful when we want to obtain a "category" of implementations
 //
@@ -39,6 +52,9 @@ func (container *ContainerInstance) Tag(tag string, bindings ...any) bool {
 		return false
 	}
 
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
 	// If we don't have any tagged types already with this tag, we'll just set and return
 	if _, ok := container.tagged[tag]; !ok {
 		container.tagged[tag] = taggedTypes
@@ -68,22 +84,221 @@ func (container *ContainerInstance) Tag(tag string, bindings ...any) bool {
 
 // Tagged - Resolve the instances from the container using the specified tag
 // Refer to Tag to see how adding tagged bindings works
+//
+// On a child container, the bindings tagged in our parents are included too, our root container's first,
+// then each child's, without duplicates. They're all resolved by this container, so our own bindings
+// & scoped instances are used. Untag can hide a parent's tagged bindings from this container.
 func (container *ContainerInstance) Tagged(tag string) []any {
+	resolved, err := container.TaggedE(tag)
+	logError(err)
+
+	return resolved
+}
+
+// Untag - Remove bindings from the tag in this container, our parent containers keep them
+// When no bindings are given, the whole tag is removed, including the bindings tagged by our parents
+// Tagging the bindings again in this container adds them back
+//
+// For example:
+//  requestContainer := Container.CreateChildContainer()
+//  requestContainer.Untag("StatServices", new(PageViewsStatService))
+func (container *ContainerInstance) Untag(tag string, bindings ...any) bool {
+	if len(bindings) == 0 {
+		container.mu.Lock()
+		defer container.mu.Unlock()
+
+		delete(container.tagged, tag)
+		container.untagged[tag] = &tagRemoval{all: true}
+
+		return true
+	}
+
+	untaggedTypes := []reflect.Type{}
+	for _, b := range bindings {
+		if binding := container.getBindingType(b); binding != nil {
+			untaggedTypes = append(untaggedTypes, binding)
+		}
+	}
+
+	if len(untaggedTypes) == 0 {
+		return false
+	}
+
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
+	removal := container.untagged[tag]
+	if removal == nil {
+		removal = &tagRemoval{}
+		container.untagged[tag] = removal
+	}
+
+	for _, untaggedType := range untaggedTypes {
+		container.tagged[tag] = removeType(container.tagged[tag], untaggedType)
+		removal.types = append(removal.types, untaggedType)
+	}
+
+	return true
+}
+
+// tagRemoval - The bindings removed from a tag with Untag, either all of them, or just types
+type tagRemoval struct {
+	all   bool
+	types []reflect.Type
+}
+
+// taggedTypes - The types tagged with tag, for this container & our parents
+// We start with our root container's, then each child removes what it untagged & adds what it tagged
+func (container *ContainerInstance) taggedTypes(tag string) []reflect.Type {
+	var chain []*ContainerInstance
+	for c := container; c != nil; c = c.ParentContainer() {
+		chain = append([]*ContainerInstance{c}, chain...)
+	}
+
+	var taggedTypes []reflect.Type
+
+	for _, c := range chain {
+		c.mu.RLock()
+		if removal := c.untagged[tag]; removal != nil {
+			if removal.all {
+				taggedTypes = nil
+			}
+			for _, untaggedType := range removal.types {
+				taggedTypes = removeType(taggedTypes, untaggedType)
+			}
+		}
+		for _, taggedType := range c.tagged[tag] {
+			if !containsType(taggedTypes, taggedType) {
+				taggedTypes = append(taggedTypes, taggedType)
+			}
+		}
+		c.mu.RUnlock()
+	}
+
+	return taggedTypes
+}
+
+// resolveTaggedSlice - Resolve every binding tagged with tag into a slice of sliceType
+// This is used to inject `inject:"tagged=..."` struct fields & InjectTagged args, unlike TaggedE, the first failure is returned
+// A tagged binding which isn't a sliceType element fails with ErrInvalidTarget
+func (container *ContainerInstance) resolveTaggedSlice(res *resolution, site resolutionSite, tag string, sliceType reflect.Type) (reflect.Value, error) {
+	taggedTypes := container.taggedTypes(tag)
+
+	resolvedSlice := reflect.MakeSlice(sliceType, 0, len(taggedTypes))
+
+	for _, taggedType := range taggedTypes {
+		resolvedBinding, err := container.makeFromBinding(res, site, taggedType)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if resolvedBinding == nil {
+			continue
+		}
+
+		value, err := assignableValue(resolvedBinding, sliceType.Elem())
+		if err != nil {
+			return reflect.Value{}, wrapError(taggedType, "binding tagged with "+tag+" is not a "+sliceType.Elem().String()+", so it can't be injected into "+sliceType.String(), err)
+		}
+
+		resolvedSlice = reflect.Append(resolvedSlice, value)
+	}
+
+	return resolvedSlice, nil
+}
+
+// TaggedE - The same as Tagged, but returns an error if any of the tagged bindings fail to resolve
+// The instances that did resolve are still returned
+func (container *ContainerInstance) TaggedE(tag string) ([]any, error) {
 	resolved := []any{}
 
-	if _, ok := container.tagged[tag]; !ok {
-		return resolved
+	taggedTypes := container.taggedTypes(tag)
+
+	if len(taggedTypes) == 0 {
+		return resolved, nil
 	}
 
-	taggedTypes := container.tagged[tag]
+	var resolveErr error
 
 	for _, taggedType := range taggedTypes {
-		resolvedBinding := container.makeFromBinding(taggedType)
+		resolvedBinding, err := container.makeFromBinding(newResolution(), topLevelSite, taggedType)
+		if err != nil {
+			if resolveErr == nil {
+				resolveErr = wrapError(taggedType, "failed to resolve binding tagged with "+tag, err)
+			}
+			continue
+		}
 		if resolvedBinding == nil {
 			continue
 		}
 		resolved = append(resolved, resolvedBinding)
 	}
 
-	return resolved
+	return resolved, resolveErr
+}
+
+// splitTaggedArgs - Remove any TaggedArg values from values, returns the tags keyed by the slice type they're injected into
+// Later values override earlier ones for the same slice type
+// If values doesn't contain any TaggedArg, it's returned as is
+func splitTaggedArgs(values []any) ([]any, map[reflect.Type]string) {
+	var taggedArgs map[reflect.Type]string
+	var remaining []any
+
+	for i, value := range values {
+		arg, ok := value.(TaggedArg)
+		if !ok {
+			if remaining != nil {
+				remaining = append(remaining, value)
+			}
+			continue
+		}
+
+		if remaining == nil {
+			remaining = append(make([]any, 0, len(values)), values[:i]...)
+		}
+		if taggedArgs == nil {
+			taggedArgs = map[reflect.Type]string{}
+		}
+		taggedArgs[arg.sliceType] = arg.tag
+	}
+
+	if taggedArgs == nil {
+		return values, nil
+	}
+
+	return remaining, taggedArgs
+}
+
+// setTaggedArgs - Set the TaggedArg values passed when binding, they're only valid for a
+// function binding, which has an arg of the slice type
+func (binding *Binding) setTaggedArgs(taggedArgs map[reflect.Type]string) error {
+	if len(taggedArgs) == 0 {
+		return nil
+	}
+
+	if !binding.isFunctionResolver {
+		return newError(ErrInvalidBinding, binding.concreteType, "InjectTagged can only be used with function bindings", nil)
+	}
+
+	functionType := reflect.TypeOf(binding.resolverFunction)
+
+	for sliceType, tag := range taggedArgs {
+		if tag == "" {
+			return newError(ErrInvalidBinding, functionType, "InjectTagged requires a tag", nil)
+		}
+
+		hasArg := false
+		for i := 0; i < functionType.NumIn(); i++ {
+			if functionType.In(i) == sliceType {
+				hasArg = true
+				break
+			}
+		}
+		if !hasArg {
+			return newError(ErrInvalidBinding, functionType, "InjectTagged(\""+tag+"\") has no "+sliceType.String()+" arg to inject", nil)
+		}
+
+		binding.taggedArgs = append(binding.taggedArgs, TaggedArg{tag: tag, sliceType: sliceType})
+	}
+
+	return nil
 }
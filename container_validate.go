@@ -0,0 +1,487 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Validate - Check every binding can be resolved from this container, without constructing anything
+// This is meant to be called once everything is registered, when booting an app, so wiring mistakes
+// are found straight away, rather than by the first request which resolves the broken binding
+//
+// Every binding, named, multi & contextual binding in this container & our parents is checked, in the
+// same way it's resolved, through its resolver function's args & its struct fields. Resolver functions,
+// extenders & resolving callbacks are never called, only BindWhenResolving conditions are.
+//
+// Resolver function args which the container can't build, anything but structs & interfaces(or pointers to them),
+// like a string or an int, are expected to be passed as Make/Call parameters. They're only checked when they're bound.
+//
+// Returns a ValidationError holding every problem found:
+//  - Dependencies which aren't bound, ErrNotBound
+//  - Dependencies which are only found through a concrete type, bound to more than one abstract, ErrAmbiguousBinding
+//  - Bindings which depend on themselves, ErrCircularDependency
+//  - Singletons which depend on a scoped binding, ErrLifetimeViolation
+//
+// For example:
+//  if err := Container.Validate(); err != nil {
+//  	log.Fatal(err)
+//  }
+func (container *ContainerInstance) Validate() error {
+	v := &validator{
+		res:      newResolution(),
+		visited:  make(map[validationNode]bool),
+		reported: make(map[string]bool),
+	}
+
+	for _, entry := range container.validationEntries() {
+		for binding := entry.binding; binding != nil; binding = binding.fallback {
+			v.visit(container, entry.owner, entry.site.step(entry.bindingType, binding), binding)
+		}
+	}
+
+	if len(v.problems) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Problems: v.problems}
+}
+
+// ValidationError - Returned from Validate, with every problem it found
+// Each problem is a ResolutionError, its Path leads from the binding being validated to the problem
+//
+// errors.Is matches any of the problems, for example:
+//  if errors.Is(err, container.ErrCircularDependency) { ... }
+type ValidationError struct {
+	Problems []error
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, 0, len(e.Problems)+1)
+	lines = append(lines, fmt.Sprintf("container: validation found %d problem(s)", len(e.Problems)))
+
+	for _, problem := range e.Problems {
+		lines = append(lines, "  - "+problem.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Is - Allows errors.Is(err, ErrNotBound) etc. to match on any of our problems
+func (e *ValidationError) Is(target error) bool {
+	for _, problem := range e.Problems {
+		if errors.Is(problem, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validator - Walks the bindings the same way resolve does, collecting problems instead of instances
+// res tracks the bindings being walked, so we get the same paths & circular dependency chains as resolving
+type validator struct {
+	res *resolution
+
+	// The singleton we're currently walking the dependencies of, for each binding on res.stack
+	singletons []*Binding
+
+	visited  map[validationNode]bool
+	reported map[string]bool
+	problems []error
+}
+
+// validationNode - A binding only needs walking once, for each container resolving it
+// Singletons which depend on it are tracked too, since a scoped binding is only a problem under one
+type validationNode struct {
+	binding   *Binding
+	resolver  *ContainerInstance
+	singleton *Binding
+}
+
+// validationEntry - A registered binding Validate starts walking from
+type validationEntry struct {
+	key         string
+	site        resolutionSite
+	bindingType reflect.Type
+	binding     *Binding
+	owner       *ContainerInstance
+}
+
+// validationEntries - Every binding which can be resolved from this container, our own bindings win over
+// our parents, the same as they do when resolving. They're sorted, so problems are always reported in the same order
+func (container *ContainerInstance) validationEntries() []validationEntry {
+	var entries []validationEntry
+
+	seenBindings := map[reflect.Type]bool{}
+	seenNamed := map[namedBindingKey]bool{}
+	seenContextual := map[contextualBindingKey]bool{}
+
+	for c := container; c != nil; c = c.ParentContainer() {
+		c.mu.RLock()
+
+		for bindingType, binding := range c.bindings {
+			if !seenBindings[bindingType] {
+				seenBindings[bindingType] = true
+				entries = append(entries, validationEntry{
+					key:         "0 " + describeType(bindingType),
+					site:        topLevelSite,
+					bindingType: bindingType,
+					binding:     binding,
+					owner:       c,
+				})
+			}
+		}
+
+		for key, binding := range c.named {
+			if !seenNamed[key] {
+				seenNamed[key] = true
+				entries = append(entries, validationEntry{
+					key:         "1 " + describeType(key.bindingType) + " " + key.name,
+					site:        topLevelSite.named(key.name),
+					bindingType: key.bindingType,
+					binding:     binding,
+					owner:       c,
+				})
+			}
+		}
+
+		for key, binding := range c.contextual {
+			if !seenContextual[key] {
+				seenContextual[key] = true
+				entries = append(entries, validationEntry{
+					key:         "2 " + describeType(key.consumer) + " " + describeType(key.abstract),
+					site:        topLevelSite,
+					bindingType: key.abstract,
+					binding:     binding,
+					owner:       c,
+				})
+			}
+		}
+
+		// Multi bindings are added to our parents', rather than replacing them, so every one of them is used
+		for elemType, bindings := range c.many {
+			for _, binding := range bindings {
+				entries = append(entries, validationEntry{
+					key:         "3 " + describeType(elemType),
+					site:        topLevelSite,
+					bindingType: elemType,
+					binding:     binding,
+					owner:       c,
+				})
+			}
+		}
+
+		c.mu.RUnlock()
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	return entries
+}
+
+// visit - Walk the dependencies of binding, as resolver would resolve them, owner is the container it was bound to
+func (v *validator) visit(resolver *ContainerInstance, owner *ContainerInstance, step ResolutionStep, binding *Binding) {
+	singleton := v.singleton()
+
+	if binding.isScoped && singleton != nil {
+		v.report(
+			"lifetime "+describeType(singleton.registeredType)+" "+describeType(step.Type),
+			v.res.fail(&step, newError(
+				ErrLifetimeViolation,
+				step.Type,
+				"singleton "+describeType(singleton.registeredType)+" depends on scoped "+describeType(step.Type)+
+					", so it would keep the same instance for every container",
+				nil,
+			)),
+		)
+	}
+
+	if err := v.res.enter(step, binding); err != nil {
+		v.report(err.Error(), err)
+		return
+	}
+	defer v.res.leave()
+
+	// Singletons are resolved by the container they were bound to, so their dependencies are too
+	if binding.isSingleton {
+		resolver = owner
+		singleton = binding
+	}
+
+	v.singletons = append(v.singletons, singleton)
+	defer func() {
+		v.singletons = v.singletons[:len(v.singletons)-1]
+	}()
+
+	node := validationNode{binding: binding, resolver: resolver, singleton: singleton}
+	if v.visited[node] {
+		return
+	}
+	v.visited[node] = true
+
+	// Instances were constructed before they were bound, they don't have any dependencies to resolve
+	if binding.isInstance || binding.invocable == nil {
+		return
+	}
+
+	if binding.isFunctionResolver {
+		v.visitFunctionArgs(resolver, binding)
+		return
+	}
+
+	v.visitStructFields(resolver, binding.invocable.bindingType)
+}
+
+// singleton - The singleton whose dependencies we're walking, or nil
+func (v *validator) singleton() *Binding {
+	if len(v.singletons) == 0 {
+		return nil
+	}
+
+	return v.singletons[len(v.singletons)-1]
+}
+
+// visitFunctionArgs - Walk the resolver function's args, the same way resolveFunctionArgs resolves them
+func (v *validator) visitFunctionArgs(resolver *ContainerInstance, binding *Binding) {
+	functionType := binding.invocable.bindingType
+
+	taggedArgs := map[reflect.Type]string{}
+	for _, taggedArg := range binding.taggedArgs {
+		taggedArgs[taggedArg.sliceType] = taggedArg.tag
+	}
+
+	for i := 0; i < functionType.NumIn(); i++ {
+		arg := functionType.In(i)
+		site := argSite(i)
+
+		if tag, ok := taggedArgs[arg]; ok {
+			v.visitTagged(resolver, site, tag)
+			continue
+		}
+
+		if name := binding.namedArgs[i]; name != "" {
+			v.visitNamed(resolver, site, name, arg)
+			continue
+		}
+
+		consumers := []reflect.Type{v.res.current()}
+		if v.isDeferred(resolver, site, consumers, arg) {
+			continue
+		}
+
+		if isParameterType(arg) && !resolver.isDependencyBound(consumers, arg) {
+			continue
+		}
+
+		v.visitDependency(resolver, site, consumers, arg)
+	}
+}
+
+// isParameterType - Check if typ is something the container can't build, so it's passed as a Make/Call parameter
+// Only structs & interfaces, or pointers to them, can be built from bindings
+func isParameterType(typ reflect.Type) bool {
+	switch pointerElemType(typ).Kind() {
+	case reflect.Struct, reflect.Interface:
+		return false
+	}
+
+	return true
+}
+
+// visitStructFields - Walk the struct's fields, the same way resolveStructFields resolves them
+func (v *validator) visitStructFields(resolver *ContainerInstance, instanceType reflect.Type) {
+	structType := indirectType(instanceType)
+	if structType.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		site := fieldSite(field.Name)
+
+		tag, hasTag, err := parseInjectTag(field)
+		if err != nil {
+			step := site.step(field.Type, nil)
+			v.report(err.Error(), v.res.fail(&step, err))
+			continue
+		}
+
+		if tag.skip || (!hasTag && resolver.Config.OnlyInjectStructFieldsWithInjectTag) {
+			continue
+		}
+
+		consumers := []reflect.Type{structType, v.res.current()}
+		required := hasTag && !tag.optional
+
+		switch {
+		case tag.tagged != "":
+			v.visitTagged(resolver, site, tag.tagged)
+		case tag.name != "":
+			if required || resolver.IsBoundNamed(tag.name, field.Type) {
+				v.visitNamed(resolver, site, tag.name, field.Type)
+			}
+		case v.isDeferred(resolver, site, consumers, field.Type):
+		case required || resolver.isDependencyBound(consumers, field.Type):
+			v.visitDependency(resolver, site, consumers, field.Type)
+		}
+	}
+}
+
+// isDeferred - Check if typ is a Lazy[T] or a provider function, which resolveDeferred would inject
+// Their T isn't resolved until it's used, so it can't be part of a cycle, but it still has to be bound
+func (v *validator) isDeferred(resolver *ContainerInstance, site resolutionSite, consumers []reflect.Type, typ reflect.Type) bool {
+	if typ.Implements(lazyInjectableType) {
+		lazyType := reflect.Zero(typ).Interface().(lazyInjectable).lazyType()
+
+		if !resolver.isDependencyBound(consumers, lazyType) {
+			v.notBound(site.step(lazyType, nil), lookupType(lazyType), typ.String()+" can't be resolved when it's used")
+		}
+
+		return true
+	}
+
+	providedType, ok := providerReturnType(typ)
+
+	return ok && resolver.isDependencyBound(consumers, providedType)
+}
+
+// visitDependency - Walk the binding resolveDependency would resolve typ from
+func (v *validator) visitDependency(resolver *ContainerInstance, site resolutionSite, consumers []reflect.Type, typ reflect.Type) {
+	if binding, boundType, owner := resolver.findContextualBinding(consumers, typ); binding != nil {
+		v.visit(resolver, owner, site.step(boundType, binding), binding)
+		return
+	}
+
+	bindingType := resolver.getBindingType(typ)
+	if bindingType == nil {
+		if !v.visitMany(resolver, site, typ) {
+			v.notBound(site.step(typ, nil), typ, "")
+		}
+		return
+	}
+
+	binding, owner := resolver.findBinding(bindingType)
+	if binding == nil {
+		v.notBound(site.step(bindingType, nil), bindingType, "")
+		return
+	}
+
+	step := site.step(bindingType, binding)
+	v.checkAmbiguous(resolver, step, typ, bindingType)
+	v.visit(resolver, owner, step, binding)
+}
+
+// visitMany - Walk the bindings resolveMany would resolve sliceType from, returns false if it wouldn't
+func (v *validator) visitMany(resolver *ContainerInstance, site resolutionSite, sliceType reflect.Type) bool {
+	if sliceType.Kind() != reflect.Slice {
+		return false
+	}
+
+	elemType := lookupType(sliceType.Elem())
+	if elemType == nil {
+		return false
+	}
+
+	bindings, declared := resolver.findManyBindings(elemType)
+	for _, owned := range bindings {
+		v.visit(resolver, owned.owner, site.step(elemType, owned.binding), owned.binding)
+	}
+
+	return declared
+}
+
+// visitTagged - Walk every binding resolveTaggedSlice would resolve for tag
+func (v *validator) visitTagged(resolver *ContainerInstance, site resolutionSite, tag string) {
+	for _, taggedType := range resolver.taggedTypes(tag) {
+		binding, owner := resolver.findBinding(taggedType)
+		if binding == nil {
+			v.notBound(site.step(taggedType, nil), taggedType, "binding tagged with "+tag+" isn't bound")
+			continue
+		}
+
+		v.visit(resolver, owner, site.step(taggedType, binding), binding)
+	}
+}
+
+// visitNamed - Walk the binding makeNamed would resolve
+func (v *validator) visitNamed(resolver *ContainerInstance, site resolutionSite, name string, bindingType reflect.Type) {
+	site = site.named(name)
+
+	binding, boundType, owner := resolver.findNamedBinding(name, bindingType)
+	if binding == nil {
+		if interfaceType := getAbstractReturnType(bindingType); interfaceType != nil {
+			bindingType = interfaceType
+		}
+
+		v.notBound(site.step(bindingType, nil), bindingType, "no binding named \""+name+"\"")
+		return
+	}
+
+	v.visit(resolver, owner, site.step(boundType, binding), binding)
+}
+
+// checkAmbiguous - When typ was only found through its concrete type, the binding is whichever abstract
+// was bound to it last. If more than one abstract is bound to it, which one we get depends on the order they were bound
+func (v *validator) checkAmbiguous(resolver *ContainerInstance, step ResolutionStep, typ reflect.Type, bindingType reflect.Type) {
+	if bindingType == getConcreteReturnType(typ) || bindingType == getAbstractReturnType(typ) {
+		return
+	}
+
+	var abstracts []string
+	seen := map[reflect.Type]bool{}
+
+	for c := resolver; c != nil; c = c.ParentContainer() {
+		c.mu.RLock()
+		for abstractType, binding := range c.bindings {
+			if seen[abstractType] {
+				continue
+			}
+			seen[abstractType] = true
+
+			if active := binding.active(); active != nil && active.concreteType == typ {
+				abstracts = append(abstracts, describeType(abstractType))
+			}
+		}
+		c.mu.RUnlock()
+	}
+
+	if len(abstracts) < 2 {
+		return
+	}
+
+	sort.Strings(abstracts)
+
+	v.report(
+		"ambiguous "+describeType(v.res.current())+" "+step.String(),
+		v.res.fail(&step, newError(
+			ErrAmbiguousBinding,
+			typ,
+			"it's bound to "+strings.Join(abstracts, ", ")+", bind it directly to pick one",
+			nil,
+		)),
+	)
+}
+
+// notBound - Report the binding at step isn't bound
+func (v *validator) notBound(step ResolutionStep, typ reflect.Type, message string) {
+	v.report(
+		"not bound "+describeType(v.res.current())+" "+step.String(),
+		v.res.fail(&step, newError(ErrNotBound, typ, message, nil)),
+	)
+}
+
+// report - Add a problem, unless one with the same key was already reported
+// The same binding can be walked under different singletons, which would find its problems again
+func (v *validator) report(key string, err error) {
+	if v.reported[key] {
+		return
+	}
+	v.reported[key] = true
+
+	v.problems = append(v.problems, err)
+}
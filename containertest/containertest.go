@@ -0,0 +1,222 @@
+// Package containertest - Helpers for testing code which is wired up with the container
+// They report failures through testing.TB, so they work with any test framework built on it
+//
+// For example:
+//  func wiring(c *container.ContainerInstance) {
+//  	c.Singleton(NewDatabase)
+//  	c.Bind((*Mailer)(nil), NewSmtpMailer)
+//  }
+//
+//  func TestWiring(t *testing.T) {
+//  	c := containertest.NewIsolated(t, wiring)
+//
+//  	containertest.AssertBound(t, c, (*Mailer)(nil))
+//  	containertest.AssertResolvesTo[*SmtpMailer](t, c, (*Mailer)(nil))
+//  	containertest.AssertSingleton(t, c, new(Database))
+//  }
+package containertest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	container "github.com/Envuso/go-ioc-container"
+)
+
+// Builder - Registers bindings on a container, usually shared by all the tests of a package
+type Builder func(c *container.ContainerInstance)
+
+// NewIsolated - Create a fresh container for this test, seeded by the builders in order
+// Once the test finishes, the container is closed, disposing what it created, and reset
+// Nothing is registered on the global container, so tests can't leak bindings into each other
+func NewIsolated(t testing.TB, builders ...Builder) *container.ContainerInstance {
+	t.Helper()
+
+	c := container.CreateContainer()
+
+	for _, build := range builders {
+		if build != nil {
+			build(c)
+		}
+	}
+
+	t.Cleanup(func() {
+		if err := c.Close(context.Background()); err != nil {
+			t.Errorf("containertest: closing the isolated container failed: %v", err)
+		}
+
+		c.Reset()
+	})
+
+	return c
+}
+
+// AssertBound - Check abstract is bound in c, or one of its parents
+// Returns whether the assertion passed
+func AssertBound(t testing.TB, c *container.ContainerInstance, abstract any) bool {
+	t.Helper()
+
+	if !c.IsBound(abstract) {
+		t.Errorf("containertest: expected %s to be bound", describe(abstract))
+		return false
+	}
+
+	return true
+}
+
+// AssertNotBound - Check abstract isn't bound in c, or any of its parents
+// Returns whether the assertion passed
+func AssertNotBound(t testing.TB, c *container.ContainerInstance, abstract any) bool {
+	t.Helper()
+
+	if c.IsBound(abstract) {
+		t.Errorf("containertest: expected %s not to be bound", describe(abstract))
+		return false
+	}
+
+	return true
+}
+
+// AssertResolvesTo - Check abstract resolves without an error, to a T
+// Returns the resolved T, or the zero T if the assertion failed
+//
+// For example:
+//  mailer := containertest.AssertResolvesTo[*SmtpMailer](t, c, (*Mailer)(nil))
+func AssertResolvesTo[T any](t testing.TB, c *container.ContainerInstance, abstract any, parameters ...any) T {
+	t.Helper()
+
+	var zero T
+
+	resolved, err := c.MakeE(abstract, parameters...)
+	if err != nil {
+		t.Errorf("containertest: expected %s to resolve, got: %v", describe(abstract), err)
+		return zero
+	}
+
+	typed, ok := resolved.(T)
+	if !ok {
+		t.Errorf(
+			"containertest: expected %s to resolve to %s, got %T",
+			describe(abstract),
+			reflect.TypeOf((*T)(nil)).Elem(),
+			resolved,
+		)
+		return zero
+	}
+
+	return typed
+}
+
+// AssertSingleton - Check resolving abstract twice gives the same instance
+// The instance must be a pointer, map, chan or func, so we can compare identities
+// Returns whether the assertion passed
+func AssertSingleton(t testing.TB, c *container.ContainerInstance, abstract any) bool {
+	t.Helper()
+
+	first, err := c.MakeE(abstract)
+	if err != nil {
+		t.Errorf("containertest: expected %s to resolve, got: %v", describe(abstract), err)
+		return false
+	}
+
+	second, err := c.MakeE(abstract)
+	if err != nil {
+		t.Errorf("containertest: expected %s to resolve a second time, got: %v", describe(abstract), err)
+		return false
+	}
+
+	firstValue, secondValue := reflect.ValueOf(first), reflect.ValueOf(second)
+
+	switch firstValue.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+	default:
+		t.Errorf("containertest: %s resolved to %T, which can't be compared by identity", describe(abstract), first)
+		return false
+	}
+
+	if firstValue.Type() != secondValue.Type() || firstValue.Pointer() != secondValue.Pointer() {
+		t.Errorf("containertest: expected %s to be a singleton, it resolved to two different instances", describe(abstract))
+		return false
+	}
+
+	return true
+}
+
+// AssertTagged - Check the bindings tagged with tag resolve, to exactly the given abstracts, in order
+// Each abstract matches an instance which implements it, when it's an interface, or is its concrete type
+// Returns whether the assertion passed
+//
+// For example:
+//  containertest.AssertTagged(t, c, "StatServices", new(PageViewsStatService), new(UserPostViewsStatService))
+func AssertTagged(t testing.TB, c *container.ContainerInstance, tag string, abstracts ...any) bool {
+	t.Helper()
+
+	tagged, err := c.TaggedE(tag)
+	if err != nil {
+		t.Errorf("containertest: expected the bindings tagged with %s to resolve, got: %v", tag, err)
+		return false
+	}
+
+	if len(tagged) != len(abstracts) {
+		t.Errorf("containertest: expected %d bindings tagged with %s, got %d", len(abstracts), tag, len(tagged))
+		return false
+	}
+
+	passed := true
+	for i, abstract := range abstracts {
+		if !matches(tagged[i], abstract) {
+			t.Errorf("containertest: expected binding %d tagged with %s to be %s, got %T", i, tag, describe(abstract), tagged[i])
+			passed = false
+		}
+	}
+
+	return passed
+}
+
+// matches - Check instance implements abstract, when it's an interface, or is abstract's concrete type
+func matches(instance any, abstract any) bool {
+	abstractType := typeOf(abstract)
+	if abstractType == nil {
+		return false
+	}
+
+	instanceType := reflect.TypeOf(instance)
+	if abstractType.Kind() == reflect.Interface {
+		return instanceType.Implements(abstractType)
+	}
+
+	for instanceType.Kind() == reflect.Pointer {
+		instanceType = instanceType.Elem()
+	}
+
+	return instanceType == abstractType
+}
+
+// typeOf - The type abstract stands for, (*Iface)(nil) & new(Iface) give the interface,
+// new(Struct) & Struct{} give the struct
+func typeOf(abstract any) reflect.Type {
+	abstractType, ok := abstract.(reflect.Type)
+	if !ok {
+		abstractType = reflect.TypeOf(abstract)
+	}
+	if abstractType == nil {
+		return nil
+	}
+
+	for abstractType.Kind() == reflect.Pointer {
+		abstractType = abstractType.Elem()
+	}
+
+	return abstractType
+}
+
+// describe - A readable name for abstract in failure messages
+func describe(abstract any) string {
+	if abstractType := typeOf(abstract); abstractType != nil {
+		return abstractType.String()
+	}
+
+	return fmt.Sprintf("%v", abstract)
+}
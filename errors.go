@@ -0,0 +1,120 @@
+package container
+
+import (
+	"errors"
+	"log"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrNotBound - The requested type(or one of its dependencies) isn't bound to the container
+	ErrNotBound = errors.New("binding not found")
+
+	// ErrInvalidTarget - The value passed to MakeTo/Call etc. can't be used by the container
+	ErrInvalidTarget = errors.New("invalid target")
+
+	// ErrInvalidBinding - The definition passed to Bind/Singleton/Instance can't be registered
+	ErrInvalidBinding = errors.New("invalid binding")
+
+	// ErrConstructorFailed - A bound resolver function returned a non-nil error, or no value at all
+	ErrConstructorFailed = errors.New("constructor failed")
+
+	// ErrCircularDependency - A binding depends on itself, either directly or through one of its dependencies
+	ErrCircularDependency = errors.New("circular dependency")
+
+	// ErrDeadlock - A singleton's construction is waiting on itself from another goroutine
+	// or SingletonWaitTimeout passed while waiting for another goroutine to construct it
+	ErrDeadlock = errors.New("singleton deadlock")
+
+	// ErrInvalidTag - A struct field has a malformed `inject` tag
+	ErrInvalidTag = errors.New("invalid inject tag")
+
+	// ErrDisposeFailed - An instance returned an error from Dispose/Close when its container was closed
+	ErrDisposeFailed = errors.New("dispose failed")
+
+	// ErrAmbiguousBinding - A dependency could be resolved from more than one binding, found by Validate
+	ErrAmbiguousBinding = errors.New("ambiguous binding")
+
+	// ErrLifetimeViolation - A singleton depends on a scoped binding, found by Validate
+	// The singleton would keep the first scoped instance, instead of one per container
+	ErrLifetimeViolation = errors.New("lifetime violation")
+)
+
+// ContainerError - Returned from the error returning api(MakeE, MakeToE, CallE, TaggedE etc)
+//
+// Use errors.Is with one of the Err* values to check what went wrong, for example:
+//  if errors.Is(err, container.ErrNotBound) { ... }
+type ContainerError struct {
+	// Kind is one of the Err* values above
+	Kind error
+
+	// Type is the type we were trying to bind or resolve when the failure happened
+	Type reflect.Type
+
+	// Message adds some extra detail about the failure
+	Message string
+
+	// Err is the underlying error, for example, the error returned from a constructor
+	Err error
+}
+
+func newError(kind error, typ reflect.Type, message string, err error) *ContainerError {
+	return &ContainerError{
+		Kind:    kind,
+		Type:    typ,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// wrapError - Adds context to an error returned from a nested resolve, while keeping its Kind
+// so errors.Is still reports the original reason for the failure
+func wrapError(typ reflect.Type, message string, err error) *ContainerError {
+	kind := ErrConstructorFailed
+
+	var containerErr *ContainerError
+	if errors.As(err, &containerErr) {
+		kind = containerErr.Kind
+	}
+
+	return newError(kind, typ, message, err)
+}
+
+func (e *ContainerError) Error() string {
+	parts := []string{"container: " + e.Kind.Error()}
+
+	if e.Type != nil {
+		parts[0] += " for type " + e.Type.String()
+	}
+	if e.Message != "" {
+		parts = append(parts, e.Message)
+	}
+	if e.Err != nil {
+		parts = append(parts, e.Err.Error())
+	}
+
+	return strings.Join(parts, ": ")
+}
+
+// Is - Allows errors.Is(err, ErrNotBound) etc. to match on our Kind
+func (e *ContainerError) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Unwrap - Returns the underlying error, so errors.Is/errors.As can reach constructor errors
+func (e *ContainerError) Unwrap() error {
+	return e.Err
+}
+
+// logError - Our non "E" api logs failures instead of returning them
+// Returns true when there was no error to log
+func logError(err error) bool {
+	if err == nil {
+		return true
+	}
+
+	log.Print(err.Error())
+
+	return false
+}
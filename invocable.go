@@ -66,42 +66,50 @@ type Invocable struct {
 	isInstantiated bool
 }
 
-func (invocable *Invocable) instantiate() {
+// instantiate - Returns the instance we were created with, or a new instance of our type
+// New instances aren't stored on the invocable, so every resolve of a binding gets a fresh
+// instance and the same invocable can be used from multiple goroutines
+func (invocable *Invocable) instantiate() reflect.Value {
+	if invocable.isInstantiated {
+		return invocable.instance
+	}
 	if invocable.typeOfBinding == "func" {
-		invocable.instantiateFunction()
+		return invocable.instantiateFunction()
 	}
 	if invocable.typeOfBinding == "struct" {
-		invocable.instantiateStruct()
+		return invocable.instantiateStruct()
 	}
+
+	return reflect.Value{}
 }
 
-func (invocable *Invocable) instantiateFunction() {
+func (invocable *Invocable) instantiateFunction() reflect.Value {
 	if invocable.typeOfBinding != "func" {
 		log.Printf("Cannot Instantiate type of %s. instantiateFunction() can only instantiate functions.", invocable.bindingType.String())
-		return
+		return reflect.Value{}
 	}
 
-	invocable.instance = reflect.New(invocable.bindingType)
-	invocable.isInstantiated = true
-
+	return reflect.New(invocable.bindingType)
 }
 
-func (invocable *Invocable) instantiateStruct() {
+func (invocable *Invocable) instantiateStruct() reflect.Value {
 	if invocable.typeOfBinding != "struct" {
 		log.Printf("Cannot Instantiate type of %s. instantiateStruct() can only instantiate structs.", invocable.bindingType.String())
-		return
+		return reflect.Value{}
 	}
 
-	invocable.instance = reflect.New(invocable.bindingType)
-	invocable.isInstantiated = true
+	return reflect.New(invocable.bindingType)
 }
 
 func (invocable *Invocable) InstantiateStructAndFill(container *ContainerInstance) reflect.Value {
-	if !invocable.isInstantiated {
-		invocable.instantiate()
-	}
+	instance, err := invocable.instantiateStructAndFill(container, newResolution())
+	logError(err)
+
+	return instance
+}
 
-	return container.resolveStructFields(invocable.bindingType, invocable.instance)
+func (invocable *Invocable) instantiateStructAndFill(container *ContainerInstance, res *resolution) (reflect.Value, error) {
+	return container.resolveStructFields(res, invocable.bindingType, invocable.instantiate())
 }
 
 // InstantiateWith - Instantiate a struct and fill its fields with values from the container
@@ -111,28 +119,25 @@ func (invocable *Invocable) InstantiateWith(container *ContainerInstance) any {
 	return resolvedStruct.Interface()
 }
 
-// CallMethodByNameWith - Call the method and assign its parameters from the passed parameters & container
-func (invocable *Invocable) CallMethodByNameWith(methodName string, container *ContainerInstance, parameters ...any) []reflect.Value {
-	if invocable.typeOfBinding != "struct" {
-		panic("CallMethodByNameWith is only usable when the Invocable instance is created with a struct.")
-	}
-	if !invocable.isInstantiated {
-		invocable.instantiate()
+// instantiateWith - The same as InstantiateWith, but returns any failures to resolve the struct fields
+func (invocable *Invocable) instantiateWith(container *ContainerInstance, res *resolution) (any, error) {
+	resolvedStruct, err := invocable.instantiateStructAndFill(container, res)
+	if err != nil {
+		return nil, err
 	}
 
-	structInstance := invocable.InstantiateStructAndFill(container)
-	method := structInstance.MethodByName(methodName)
+	return resolvedStruct.Interface(), nil
+}
 
-	return method.Call(container.ResolveFunctionArgs(method, parameters...))
+// CallMethodByNameWith - Call the method and assign its parameters from the passed parameters & container
+func (invocable *Invocable) CallMethodByNameWith(methodName string, container *ContainerInstance, parameters ...any) []reflect.Value {
+	return invocable.CallMethodByNameWithArgInterceptor(methodName, container, noArgInterceptor, parameters...)
 }
 
 func (invocable *Invocable) CallMethodByNameWithArgInterceptor(methodName string, container *ContainerInstance, interceptor FuncArgResolverInterceptor, parameters ...any) []reflect.Value {
 	if invocable.typeOfBinding != "struct" {
 		panic("CallMethodByNameWith is only usable when the Invocable instance is created with a struct.")
 	}
-	if !invocable.isInstantiated {
-		invocable.instantiate()
-	}
 
 	structInstance := invocable.InstantiateStructAndFill(container)
 	method := structInstance.MethodByName(methodName)
@@ -142,11 +147,21 @@ func (invocable *Invocable) CallMethodByNameWithArgInterceptor(methodName string
 
 // CallMethodWith - Call the method and assign its parameters from the passed parameters & container
 func (invocable *Invocable) CallMethodWith(container *ContainerInstance, parameters ...any) []reflect.Value {
-	if !invocable.isInstantiated {
-		invocable.instantiate()
-	}
+	instance := invocable.instantiate()
 
-	return invocable.instance.Call(
-		container.ResolveFunctionArgs(invocable.instance, parameters...),
+	return instance.Call(
+		container.ResolveFunctionArgs(instance, parameters...),
 	)
 }
+
+// callMethodWith - The same as CallMethodWith, but the method isn't called if any of its args fail to resolve
+func (invocable *Invocable) callMethodWith(container *ContainerInstance, res *resolution, parameters ...any) ([]reflect.Value, error) {
+	instance := invocable.instantiate()
+
+	args, err := container.resolveFunctionArgs(res, instance, noArgInterceptor, parameters...)
+	if err != nil {
+		return nil, err
+	}
+
+	return instance.Call(args), nil
+}
@@ -0,0 +1,213 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// resolution - Tracks the bindings currently being resolved for a single Make/Call
+// Every nested resolve(function args, struct fields, singletons) is pushed on to the stack
+// while it's being built, so if a binding asks for itself again, we've found a cycle.
+// When something fails, the stack is the path we report in ResolutionError
+type resolution struct {
+	stack []*resolutionFrame
+
+	// The singleton this resolution is waiting for another goroutine to construct
+	// Guarded by singletonWaits, it's read by other goroutines to detect deadlocks
+	waitingOn *pendingSingleton
+}
+
+type resolutionFrame struct {
+	binding *Binding
+	step    ResolutionStep
+}
+
+// resolutionSite - Where a binding is being resolved from, so it can be reported in a ResolutionStep
+type resolutionSite struct {
+	argIndex int
+	field    string
+	name     string
+}
+
+// topLevelSite - The binding was requested directly, via Make/Tagged etc.
+var topLevelSite = resolutionSite{argIndex: -1}
+
+func argSite(index int) resolutionSite {
+	return resolutionSite{argIndex: index}
+}
+
+func fieldSite(name string) resolutionSite {
+	return resolutionSite{argIndex: -1, field: name}
+}
+
+// named - The same site, resolving the binding registered under name
+func (site resolutionSite) named(name string) resolutionSite {
+	site.name = name
+	return site
+}
+
+func newResolution() *resolution {
+	return &resolution{}
+}
+
+// step - Create the ResolutionStep for a binding resolved from site
+// binding is nil when the type isn't bound
+func (site resolutionSite) step(bindingType reflect.Type, binding *Binding) ResolutionStep {
+	step := ResolutionStep{
+		Type:     bindingType,
+		ArgIndex: site.argIndex,
+		Field:    site.field,
+		Name:     site.name,
+	}
+
+	if binding != nil {
+		step.Kind = binding.bindingType
+	}
+
+	return step
+}
+
+// enter - Push the binding on to the stack, if it's already being resolved
+// we'll return an ErrCircularDependency error which describes the full chain
+func (res *resolution) enter(step ResolutionStep, binding *Binding) error {
+	for i, frame := range res.stack {
+		if frame.binding != binding {
+			continue
+		}
+
+		chain := make([]string, 0, len(res.stack)-i+1)
+		for _, f := range res.stack[i:] {
+			chain = append(chain, describeType(f.step.Type))
+		}
+		chain = append(chain, describeType(step.Type))
+
+		return res.fail(&step, newError(ErrCircularDependency, step.Type, strings.Join(chain, " -> "), nil))
+	}
+
+	res.stack = append(res.stack, &resolutionFrame{
+		binding: binding,
+		step:    step,
+	})
+
+	return nil
+}
+
+// leave - Pop the binding that was last entered off of the stack
+func (res *resolution) leave() {
+	res.stack = res.stack[:len(res.stack)-1]
+}
+
+// current - The type of the binding currently being resolved, nil when nothing has been entered
+func (res *resolution) current() reflect.Type {
+	if len(res.stack) == 0 {
+		return nil
+	}
+
+	return res.stack[len(res.stack)-1].step.Type
+}
+
+// fail - Wrap err in a ResolutionError, with the current stack as its path
+// If failed isn't nil, it's added as the final step, for bindings which never made it on to the stack
+// An error which is already a ResolutionError came from deeper in the chain, so it's returned as is
+func (res *resolution) fail(failed *ResolutionStep, err error) error {
+	var resolutionErr *ResolutionError
+	if errors.As(err, &resolutionErr) {
+		return err
+	}
+
+	path := make([]ResolutionStep, 0, len(res.stack)+1)
+	for _, frame := range res.stack {
+		path = append(path, frame.step)
+	}
+	if failed != nil {
+		path = append(path, *failed)
+	}
+
+	path[len(path)-1].Err = err
+
+	return &ResolutionError{Path: path, Err: err}
+}
+
+// ResolutionStep - A single binding in the chain from a top level Make/Call down to a failure
+type ResolutionStep struct {
+	// Type is the type the binding was looked up with
+	Type reflect.Type
+
+	// Kind is the Binding kind, Function, Concrete, Abstract or Singleton
+	// It's empty when Type isn't bound
+	Kind string
+
+	// ArgIndex is the index of the function arg which required Type, or -1 when it wasn't a function arg
+	ArgIndex int
+
+	// Field is the name of the struct field which required Type, or empty when it wasn't a struct field
+	Field string
+
+	// Name is the name of the binding which was requested, or empty when it wasn't a named binding
+	Name string
+
+	// Err is only set on the final step of the path, it's the reason this binding failed to resolve
+	Err error
+}
+
+func (step ResolutionStep) String() string {
+	description := describeType(step.Type)
+
+	if step.Kind != "" {
+		description += " (" + step.Kind + ")"
+	} else {
+		description += " (not bound)"
+	}
+
+	if step.Name != "" {
+		description = "\"" + step.Name + "\" " + description
+	}
+
+	if step.Field != "" {
+		return "field " + step.Field + ": " + description
+	}
+	if step.ArgIndex >= 0 {
+		return fmt.Sprintf("arg(%d): %s", step.ArgIndex, description)
+	}
+
+	return description
+}
+
+// ResolutionError - Returned when something fails while resolving a binding
+// Path holds every binding from the top level Make/Call down to the one which failed, so
+// it's clear who required the failing binding. Err(and Unwrap) is the original failure, so
+// errors.Is(err, ErrNotBound) & errors.Is(err, someConstructorErr) still work
+type ResolutionError struct {
+	Path []ResolutionStep
+	Err  error
+}
+
+func (e *ResolutionError) Error() string {
+	steps := make([]string, len(e.Path))
+	for i, step := range e.Path {
+		steps[i] = step.String()
+	}
+
+	return "container: failed to resolve " + strings.Join(steps, " -> ") + ": " + e.Err.Error()
+}
+
+// Unwrap - Returns the original failure
+func (e *ResolutionError) Unwrap() error {
+	return e.Err
+}
+
+// describeType - Format a type for a dependency chain
+// Structs are always instantiated as pointers, so we'll show them as one
+// For example: *tests.A -> tests.B(iface) -> *tests.A
+func describeType(typ reflect.Type) string {
+	switch typ.Kind() {
+	case reflect.Interface:
+		return typ.String() + "(iface)"
+	case reflect.Struct:
+		return "*" + typ.String()
+	}
+
+	return typ.String()
+}
@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// ABSTRACT -> RESOLVER FUNCTION BINDINGS
+//
+
+type wrongService struct{}
+
+func (w *wrongService) Disk() string {
+	return "wrong"
+}
+
+func TestAbstractBindingCallsResolverFunction(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(new(serviceAbstract), newServiceConcrete)
+
+	service := containertest.AssertResolvesTo[*serviceConcrete](t, container, new(serviceAbstract))
+	assert.Equal(t, "plain service concrete", service.Message())
+}
+
+func TestAbstractBindingResolverReturningInterface(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(new(anotherServiceAbstract), newAnotherService)
+
+	assert.Equal(t, "Another service", Container.MustResolve[anotherServiceAbstract](container).Message())
+}
+
+func TestAbstractBindingInjectsResolverArgs(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(new(anotherServiceAbstract), newAnotherService)
+	container.Bind(new(serviceAbstract), func(another anotherServiceAbstract) *serviceConcrete {
+		return &serviceConcrete{message: "with " + another.Message(), anotherService: another}
+	})
+
+	assert.Equal(t, "with Another service", Container.MustResolve[serviceAbstract](container).Message())
+}
+
+func TestAbstractBindingResolverWithError(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	failure := errors.New("no connection")
+
+	container.Bind(new(serviceAbstract), func() (*serviceConcrete, error) {
+		return &serviceConcrete{message: "connected"}, nil
+	})
+	assert.Equal(t, "connected", Container.MustResolve[serviceAbstract](container).Message())
+
+	container.Bind(new(serviceAbstract), func() (*serviceConcrete, error) {
+		return nil, failure
+	})
+	_, err := Container.Resolve[serviceAbstract](container)
+	assert.ErrorIs(t, err, Container.ErrConstructorFailed)
+	assert.ErrorIs(t, err, failure)
+}
+
+// capturedLogs - Returns everything logged while calling fn
+func capturedLogs(fn func()) string {
+	var logs bytes.Buffer
+
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	fn()
+
+	return logs.String()
+}
+
+func TestResolverWithErrorDoesNotLogUnhandledReturns(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	newConnected := func() (*serviceConcrete, error) {
+		return &serviceConcrete{message: "connected"}, nil
+	}
+
+	logs := capturedLogs(func() {
+		container.Bind(new(serviceAbstract), newConnected)
+		container.Singleton(newConnected)
+	})
+	assert.Empty(t, logs)
+
+	// Only the first return value of anything else is used
+	logs = capturedLogs(func() {
+		container.Bind(new(serviceAbstract), func() (*serviceConcrete, int) {
+			return &serviceConcrete{}, 1
+		})
+	})
+	assert.Contains(t, logs, "Only the first arg is handled")
+}
+
+func TestAbstractSingletonAndNamedBindingsCallResolverFunction(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Singleton(new(serviceAbstract), newServiceConcrete)
+	container.BindNamed("replica", new(database), newReplicaDatabase)
+
+	containertest.AssertSingleton(t, container, new(serviceAbstract))
+	assert.Equal(t, "plain service concrete", Container.MustResolve[serviceAbstract](container).Message())
+
+	replica, err := Container.ResolveNamed[database](container, "replica")
+	assert.NoError(t, err)
+	assert.Equal(t, "replica", replica.Host())
+}
+
+func TestAbstractBindingRejectsResolverWithWrongReturnType(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	err := container.BindE(new(storage), newServiceConcrete)
+	assert.ErrorIs(t, err, Container.ErrInvalidBinding)
+
+	err = container.BindE(new(storage), func() {})
+	assert.ErrorIs(t, err, Container.ErrInvalidBinding)
+}
+
+func TestAbstractBindingChecksResolvedInstance(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	// The function returns an interface, so what it returns is only checked when it's resolved
+	container.Bind(new(serviceAbstract), func() any { return &wrongService{} })
+
+	_, err := Container.Resolve[serviceAbstract](container)
+	assert.ErrorIs(t, err, Container.ErrInvalidBinding)
+	assert.Contains(t, err.Error(), "doesn't implement tests.serviceAbstract")
+}
@@ -3,7 +3,7 @@ package tests
 import (
 	"testing"
 
-	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
 )
 
 //
@@ -11,35 +11,27 @@ import (
 //
 
 func TestBindingAbstractInterfaceToConcreteImplementation(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	if !container.Bind((*serviceAbstract)(nil), serviceConcrete{}) {
 		t.Fatal("Could not bind serviceAbstract to serviceConcrete")
 	}
 
-	if !container.IsBound((*serviceAbstract)(nil)) {
-		t.Fatal("Could not find binding for serviceAbstract")
-	}
-	if !container.IsBound(serviceConcrete{}) {
-		t.Fatal("Could not find binding for serviceConcrete")
-	}
+	containertest.AssertBound(t, container, (*serviceAbstract)(nil))
+	containertest.AssertBound(t, container, serviceConcrete{})
 }
 
 func TestBindingAbstractInterfaceToConcreteImplementationUsingPtr(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	if !container.Bind((*serviceAbstract)(nil), &serviceConcrete{}) {
 		t.Fatal("Could not bind serviceAbstract to serviceConcrete")
 	}
 
-	if !container.IsBound((*serviceAbstract)(nil)) {
-		t.Fatal("Could not find binding for serviceAbstract")
-	}
-	if !container.IsBound(serviceConcrete{}) {
-		t.Fatal("Could not find binding for serviceConcrete")
-	}
+	containertest.AssertBound(t, container, (*serviceAbstract)(nil))
+	containertest.AssertBound(t, container, serviceConcrete{})
 }
 
 func TestBindingAbstractToConcreteViaFunction(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	bound := container.Bind(func() serviceAbstract {
 		return &serviceConcrete{}
 	})
@@ -47,13 +39,11 @@ func TestBindingAbstractToConcreteViaFunction(t *testing.T) {
 	if !bound {
 		t.Fatal("Could not bind serviceAbstract to serviceConcrete via function")
 	}
-	if !container.IsBound((*serviceAbstract)(nil)) {
-		t.Fatal("Could not find binding for serviceAbstract")
-	}
+	containertest.AssertBound(t, container, (*serviceAbstract)(nil))
 }
 
 func TestBindingAbstractToConcreteViaFunctionWithAbstractToFunctionArgs(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	bound := container.Bind((*serviceAbstract)(nil), func() *serviceConcrete {
 		return &serviceConcrete{}
 	})
@@ -61,10 +51,6 @@ func TestBindingAbstractToConcreteViaFunctionWithAbstractToFunctionArgs(t *testi
 	if !bound {
 		t.Fatal("Could not bind serviceAbstract to serviceConcrete via function")
 	}
-	if !container.IsBound((*serviceAbstract)(nil)) {
-		t.Fatal("Could not find binding for serviceAbstract")
-	}
-	if !container.IsBound(serviceConcrete{}) {
-		t.Fatal("Could not find binding for serviceConcrete")
-	}
+	containertest.AssertBound(t, container, (*serviceAbstract)(nil))
+	containertest.AssertBound(t, container, serviceConcrete{})
 }
@@ -3,7 +3,7 @@ package tests
 import (
 	"testing"
 
-	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
 )
 
 //
@@ -11,43 +11,33 @@ import (
 //
 
 func TestChildBindingDoesNotAffectParent(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 
 	childContainer := container.CreateChildContainer()
 	childContainer.Singleton(createSingletonServiceOne)
 
-	if !childContainer.IsBound(new(serviceConcrete)) {
-		t.Fatal("Could not find binding in child container for serviceConcrete")
-	}
+	containertest.AssertBound(t, childContainer, new(serviceConcrete))
 
-	if container.IsBound(new(serviceConcrete)) {
-		t.Fatal("Service Concrete is bound to parent container and shouldn't be")
-	}
+	containertest.AssertNotBound(t, container, new(serviceConcrete))
 
 }
 
 func TestResettingContainer(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	container.Singleton(createSingletonServiceOne)
 
-	if !container.IsBound(new(serviceConcrete)) {
-		t.Fatal("Could not find binding in container")
-	}
+	containertest.AssertBound(t, container, new(serviceConcrete))
 
 	container.Reset()
 
-	if container.IsBound(new(serviceConcrete)) {
-		t.Fatal("Binding is still set in container after reset?")
-	}
+	containertest.AssertNotBound(t, container, new(serviceConcrete))
 }
 
 func TestClearingContainerInstances(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	container.Singleton(createSingletonServiceOne)
 
-	if !container.IsBound(new(serviceConcrete)) {
-		t.Fatal("Could not find binding in container")
-	}
+	containertest.AssertBound(t, container, new(serviceConcrete))
 
 	var service *serviceConcrete
 	container.MakeTo(&service)
@@ -55,9 +45,7 @@ func TestClearingContainerInstances(t *testing.T) {
 
 	container.ClearInstances()
 
-	if !container.IsBound(new(serviceConcrete)) {
-		t.Fatal("Could not find binding in container")
-	}
+	containertest.AssertBound(t, container, new(serviceConcrete))
 
 	var nextService *serviceConcrete
 	container.MakeTo(&nextService)
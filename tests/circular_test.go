@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// CIRCULAR DEPENDENCIES
+//
+
+type circularA struct {
+	B circularB
+}
+
+type circularB interface {
+	Name() string
+}
+
+type circularBImpl struct {
+	a *circularA
+}
+
+func (b *circularBImpl) Name() string {
+	return "b"
+}
+
+func newCircularB(a *circularA) circularB {
+	return &circularBImpl{a: a}
+}
+
+type circularSingleton struct {
+	self *circularSingleton
+}
+
+func TestCircularDependencyBetweenStructFieldAndConstructor(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(new(circularA))
+	container.Bind(newCircularB)
+
+	_, err := container.MakeE(new(circularA))
+
+	assert.ErrorIs(t, err, Container.ErrCircularDependency)
+	assert.Contains(t, err.Error(), "*tests.circularA -> tests.circularB(iface) -> *tests.circularA")
+
+	// The non "E" api should log & return nil rather than overflowing the stack
+	assert.Nil(t, container.Make(new(circularA)))
+}
+
+func TestCircularDependencyInSingletonConstructor(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Singleton(func(self *circularSingleton) *circularSingleton {
+		return &circularSingleton{self: self}
+	})
+
+	_, err := Container.Resolve[*circularSingleton](container)
+
+	assert.ErrorIs(t, err, Container.ErrCircularDependency)
+	assert.Contains(t, err.Error(), "*tests.circularSingleton -> *tests.circularSingleton")
+}
+
+func TestCircularDependencyInCall(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(new(circularA))
+	container.Bind(newCircularB)
+
+	called := false
+	_, err := container.CallE(func(b circularB) {
+		called = true
+	})
+
+	assert.False(t, called)
+	assert.ErrorIs(t, err, Container.ErrCircularDependency)
+	assert.Contains(t, err.Error(), "tests.circularB(iface) -> *tests.circularA -> tests.circularB(iface)")
+}
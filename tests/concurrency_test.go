@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// CONCURRENT USE - run with `go test -race ./...`
+//
+
+const concurrentWorkers = 50
+
+func runConcurrently(workers int, work func(worker int)) {
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func(worker int) {
+			defer wg.Done()
+			work(worker)
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+func TestConcurrentMakeAndBind(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(newAnotherService)
+	container.Bind(new(TestingStr))
+
+	runConcurrently(concurrentWorkers, func(worker int) {
+		// Late bindings while other goroutines are resolving
+		if worker%5 == 0 {
+			container.Bind(newServiceConcreteTwo)
+			container.Instance(&serviceConcrete{message: fmt.Sprintf("instance %d", worker)})
+		}
+
+		service, err := Container.Resolve[anotherServiceAbstract](container)
+		assert.NoError(t, err)
+		assert.Equal(t, "Another service", service.Message())
+
+		_, err = container.MakeE(new(TestingStr))
+		assert.NoError(t, err)
+
+		container.IsBound(new(serviceConcreteTwo))
+		container.Make(new(serviceConcrete))
+	})
+}
+
+func TestConcurrentSingletonResolvesOneInstance(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Singleton(newServiceConcrete)
+
+	instances := make([]*serviceConcrete, concurrentWorkers)
+
+	runConcurrently(concurrentWorkers, func(worker int) {
+		instances[worker] = Container.MustResolve[*serviceConcrete](container)
+	})
+
+	for _, instance := range instances {
+		assert.Same(t, instances[0], instance)
+	}
+}
+
+func TestConcurrentChildContainers(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(newAnotherService)
+
+	runConcurrently(concurrentWorkers, func(worker int) {
+		child := container.CreateChildContainer()
+		child.Instance(&serviceConcrete{message: fmt.Sprintf("child %d", worker)})
+
+		var service *serviceConcrete
+		assert.NoError(t, child.MakeToE(&service))
+		assert.Equal(t, fmt.Sprintf("child %d", worker), service.Message())
+
+		_, err := Container.Resolve[anotherServiceAbstract](child)
+		assert.NoError(t, err)
+
+		if worker%10 == 0 {
+			container.ClearInstances()
+		}
+	})
+}
+
+func TestConcurrentTagging(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(newServiceConcrete)
+	container.Bind(newServiceConcreteTwo)
+
+	runConcurrently(concurrentWorkers, func(worker int) {
+		if worker%2 == 0 {
+			container.Tag("Services", new(serviceConcrete), new(serviceConcreteTwo))
+			return
+		}
+
+		_, err := container.TaggedE("Services")
+		assert.NoError(t, err)
+	})
+
+	assert.Len(t, container.Tagged("Services"), 2)
+}
+
+func TestConcurrentContainerTypes(t *testing.T) {
+	runConcurrently(concurrentWorkers, func(worker int) {
+		pkgType := Container.ContainerTypes.Of(new(TestingInter))
+		pkgType.Save()
+
+		assert.True(t, Container.ContainerTypes.Has(new(TestingInter)))
+	})
+}
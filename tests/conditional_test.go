@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// CONDITIONAL BINDINGS
+//
+
+func TestBindIfOnlyBindsUnboundTypes(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	assert.True(t, container.BindIf(func() storage { return &localStorage{} }))
+	assert.False(t, container.BindIf(func() storage { return &s3Storage{} }))
+
+	assert.Equal(t, "local", Container.MustResolve[storage](container).Disk())
+}
+
+func TestBindIfDoesNotOverrideApplicationBinding(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	// The application registers first, then the library's default
+	container.Bind(func() storage { return &s3Storage{} })
+	bound, err := container.BindIfE(func() storage { return &localStorage{} })
+	assert.NoError(t, err)
+	assert.False(t, bound)
+
+	assert.Equal(t, "s3", Container.MustResolve[storage](container).Disk())
+}
+
+func TestBindIfChecksParentContainers(t *testing.T) {
+	parent := containertest.NewIsolated(t)
+	parent.Bind(func() storage { return &s3Storage{} })
+
+	child := parent.CreateChildContainer()
+	assert.False(t, child.BindIf(func() storage { return &localStorage{} }))
+	assert.Equal(t, "s3", Container.MustResolve[storage](child).Disk())
+}
+
+func TestBindIfRejectsInvalidBindings(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	bound, err := container.BindIfE(func() {})
+	assert.False(t, bound)
+	assert.True(t, errors.Is(err, Container.ErrInvalidBinding))
+}
+
+func TestSingletonIf(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	assert.True(t, container.SingletonIf(func() storage { return &localStorage{} }))
+	assert.False(t, container.SingletonIf(func() storage { return &s3Storage{} }))
+
+	containertest.AssertSingleton(t, container, new(storage))
+	containertest.AssertResolvesTo[*localStorage](t, container, new(storage))
+}
+
+func TestInstanceIf(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	first := &localStorage{}
+
+	assert.True(t, container.InstanceIf(first))
+	assert.False(t, container.InstanceIf(&localStorage{}))
+
+	assert.Same(t, first, Container.MustResolve[*localStorage](container))
+
+	bound, err := container.InstanceIfE(nil)
+	assert.False(t, bound)
+	assert.True(t, errors.Is(err, Container.ErrInvalidBinding))
+}
+
+func TestBindWhenIsEvaluatedWhenRegistering(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	enabled := false
+
+	assert.False(t, container.BindWhen(func() bool { return enabled }, func() storage { return &s3Storage{} }))
+	containertest.AssertNotBound(t, container, new(storage))
+
+	enabled = true
+	assert.True(t, container.BindWhen(func() bool { return enabled }, func() storage { return &s3Storage{} }))
+
+	// Changing the predicate's result later doesn't matter
+	enabled = false
+	assert.Equal(t, "s3", Container.MustResolve[storage](container).Disk())
+
+	_, err := container.BindWhenE(nil, func() storage { return &s3Storage{} })
+	assert.True(t, errors.Is(err, Container.ErrInvalidBinding))
+
+	_, err = container.BindWhenE(func() bool { return false }, func() {})
+	assert.True(t, errors.Is(err, Container.ErrInvalidBinding))
+}
+
+func TestBindWhenResolvingIsEvaluatedEachResolve(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	var production int32
+
+	container.Bind(func() storage { return &localStorage{} })
+	assert.True(t, container.BindWhenResolving(func() bool { return atomic.LoadInt32(&production) == 1 }, func() storage { return &s3Storage{} }))
+
+	assert.Equal(t, "local", Container.MustResolve[storage](container).Disk())
+
+	atomic.StoreInt32(&production, 1)
+	assert.Equal(t, "s3", Container.MustResolve[storage](container).Disk())
+
+	atomic.StoreInt32(&production, 0)
+	assert.Equal(t, "local", Container.MustResolve[storage](container).Disk())
+}
+
+func TestBindWhenResolvingFallsBackToParent(t *testing.T) {
+	parent := containertest.NewIsolated(t)
+	parent.Singleton(func() storage { return &localStorage{} })
+
+	enabled := false
+	child := parent.CreateChildContainer()
+	child.BindWhenResolving(func() bool { return enabled }, func() storage { return &s3Storage{} })
+
+	assert.Same(t, Container.MustResolve[storage](parent), Container.MustResolve[storage](child))
+
+	enabled = true
+	assert.Equal(t, "s3", Container.MustResolve[storage](child).Disk())
+}
+
+func TestBindWhenResolvingWithoutFallbackIsNotBound(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.BindWhenResolving(func() bool { return false }, func() storage { return &s3Storage{} })
+
+	containertest.AssertNotBound(t, container, new(storage))
+
+	_, err := Container.Resolve[storage](container)
+	assert.True(t, errors.Is(err, Container.ErrNotBound))
+
+	// BindIf sees the type as unbound while the predicate is false
+	assert.True(t, container.BindIf(func() storage { return &localStorage{} }))
+	assert.Equal(t, "local", Container.MustResolve[storage](container).Disk())
+}
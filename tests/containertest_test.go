@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// CONTAINERTEST HELPERS
+//
+
+// recordingT - Records the failures reported by the containertest assertions, instead of failing our test
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+type closingStorage struct {
+	closed bool
+}
+
+func (s *closingStorage) Close() error {
+	s.closed = true
+	return nil
+}
+
+func storageWiring(c *Container.ContainerInstance) {
+	c.Singleton(func() storage { return &localStorage{} })
+	c.Bind(new(s3Storage))
+	c.Bind(new(countedStorage))
+	c.Tag("Storages", new(storage), new(s3Storage))
+}
+
+func TestNewIsolatedSeedsFromBuilders(t *testing.T) {
+	container := containertest.NewIsolated(t, storageWiring, func(c *Container.ContainerInstance) {
+		c.Bind(new(localStorage))
+	})
+
+	assert.NotSame(t, Container.Container, container)
+	assert.Nil(t, container.ParentContainer())
+	containertest.AssertBound(t, container, new(storage))
+	containertest.AssertBound(t, container, new(localStorage))
+	containertest.AssertNotBound(t, Container.Container, new(storage))
+}
+
+func TestNewIsolatedClosesContainer(t *testing.T) {
+	var container *Container.ContainerInstance
+	var service *closingStorage
+
+	t.Run("isolated", func(t *testing.T) {
+		container = containertest.NewIsolated(t, func(c *Container.ContainerInstance) {
+			c.Singleton(new(closingStorage))
+		})
+		service = Container.MustResolve[*closingStorage](container)
+	})
+
+	assert.True(t, service.closed)
+	assert.False(t, container.IsBound(new(closingStorage)))
+}
+
+func TestAssertionsPass(t *testing.T) {
+	container := containertest.NewIsolated(t, storageWiring)
+	recorder := &recordingT{TB: t}
+
+	assert.True(t, containertest.AssertBound(recorder, container, new(storage)))
+	assert.True(t, containertest.AssertNotBound(recorder, container, new(mailer)))
+	assert.True(t, containertest.AssertSingleton(recorder, container, new(storage)))
+	assert.True(t, containertest.AssertTagged(recorder, container, "Storages", new(storage), new(s3Storage)))
+
+	resolved := containertest.AssertResolvesTo[*localStorage](recorder, container, new(storage))
+	assert.NotNil(t, resolved)
+
+	assert.Empty(t, recorder.errors)
+}
+
+func TestAssertionsFail(t *testing.T) {
+	container := containertest.NewIsolated(t, storageWiring)
+	recorder := &recordingT{TB: t}
+
+	assert.False(t, containertest.AssertBound(recorder, container, new(mailer)))
+	assert.False(t, containertest.AssertNotBound(recorder, container, new(storage)))
+	assert.False(t, containertest.AssertSingleton(recorder, container, new(countedStorage)))
+	assert.False(t, containertest.AssertSingleton(recorder, container, new(mailer)))
+	assert.False(t, containertest.AssertTagged(recorder, container, "Storages", new(storage)))
+	assert.False(t, containertest.AssertTagged(recorder, container, "Storages", new(s3Storage), new(storage)))
+
+	assert.Nil(t, containertest.AssertResolvesTo[*s3Storage](recorder, container, new(storage)))
+	assert.Nil(t, containertest.AssertResolvesTo[mailer](recorder, container, new(mailer)))
+
+	assert.Len(t, recorder.errors, 8)
+	assert.Contains(t, recorder.errors[0], "expected tests.mailer to be bound")
+}
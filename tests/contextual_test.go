@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// CONTEXTUAL BINDINGS
+//
+
+type storage interface {
+	Disk() string
+}
+
+type localStorage struct{}
+
+func (s *localStorage) Disk() string {
+	return "local"
+}
+
+type s3Storage struct{}
+
+func (s *s3Storage) Disk() string {
+	return "s3"
+}
+
+type contextualReportService struct {
+	Storage storage
+}
+
+type contextualAvatarService struct {
+	storage storage
+}
+
+func newContextualAvatarService(storage storage) *contextualAvatarService {
+	return &contextualAvatarService{storage: storage}
+}
+
+type contextualLazyService struct {
+	Storage Container.Lazy[storage]
+}
+
+func bindStorages(container *Container.ContainerInstance) {
+	container.Bind(func() storage { return &localStorage{} })
+	container.Bind(new(contextualReportService))
+	container.Bind(newContextualAvatarService)
+}
+
+func TestContextualBindingForStructField(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	bindStorages(container)
+
+	assert.True(t, container.When(new(contextualReportService)).Needs(new(storage)).Give(new(s3Storage)))
+
+	assert.Equal(t, "s3", Container.MustResolve[*contextualReportService](container).Storage.Disk())
+	assert.Equal(t, "local", Container.MustResolve[*contextualAvatarService](container).storage.Disk())
+	assert.Equal(t, "local", Container.MustResolve[storage](container).Disk())
+}
+
+func TestContextualBindingForFunctionArg(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	bindStorages(container)
+
+	container.When(newContextualAvatarService).Needs(new(storage)).Give(func() storage {
+		return &s3Storage{}
+	})
+
+	assert.Equal(t, "s3", Container.MustResolve[*contextualAvatarService](container).storage.Disk())
+	assert.Equal(t, "local", Container.MustResolve[*contextualReportService](container).Storage.Disk())
+}
+
+func TestContextualBindingForLazy(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	bindStorages(container)
+	container.Bind(new(contextualLazyService))
+
+	container.When(new(contextualLazyService)).Needs(new(storage)).Give(new(s3Storage))
+
+	assert.Equal(t, "s3", Container.MustResolve[*contextualLazyService](container).Storage.Get().Disk())
+}
+
+func TestContextualBindingInChildContainer(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	bindStorages(container)
+
+	child := container.CreateChildContainer()
+	child.When(new(contextualReportService)).Needs(new(storage)).Give(new(s3Storage))
+
+	assert.Equal(t, "s3", Container.MustResolve[*contextualReportService](child).Storage.Disk())
+	assert.Equal(t, "local", Container.MustResolve[*contextualReportService](container).Storage.Disk())
+}
+
+func TestInvalidContextualBindingsAreRejected(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	assert.ErrorIs(t, container.When().Needs(new(storage)).GiveE(new(s3Storage)), Container.ErrInvalidBinding)
+	assert.ErrorIs(t, container.When(new(contextualReportService)).GiveE(new(s3Storage)), Container.ErrInvalidBinding)
+	assert.ErrorIs(
+		t,
+		container.When(new(contextualReportService)).Needs(new(storage)).GiveE(new(serviceConcrete)),
+		Container.ErrInvalidBinding,
+	)
+}
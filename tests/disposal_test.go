@@ -0,0 +1,307 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// DISPOSAL
+//
+
+var errClose = errors.New("failed to close")
+
+type disposalLog struct {
+	closed []string
+}
+
+type closingDatabase struct {
+	log *disposalLog
+}
+
+func (d *closingDatabase) Close() error {
+	d.log.closed = append(d.log.closed, "database")
+	return nil
+}
+
+type disposingRepository struct {
+	log      *disposalLog
+	Database *closingDatabase
+}
+
+func (r *disposingRepository) Dispose(ctx context.Context) error {
+	r.log.closed = append(r.log.closed, "repository")
+	return nil
+}
+
+type closingRequest struct {
+	log *disposalLog
+}
+
+func (r *closingRequest) Close() error {
+	r.log.closed = append(r.log.closed, "request")
+	return nil
+}
+
+type failingCloser struct{}
+
+func (f *failingCloser) Close() error {
+	return errClose
+}
+
+func TestCloseDisposesInReverseCreationOrder(t *testing.T) {
+	log := &disposalLog{}
+
+	container := containertest.NewIsolated(t)
+	container.Singleton(func() *closingDatabase {
+		return &closingDatabase{log: log}
+	})
+	container.Singleton(func(database *closingDatabase) *disposingRepository {
+		return &disposingRepository{log: log, Database: database}
+	})
+
+	Container.MustResolve[*disposingRepository](container)
+
+	assert.NoError(t, container.Close(context.Background()))
+	assert.Equal(t, []string{"repository", "database"}, log.closed)
+
+	// Closed instances aren't handed out again
+	log.closed = nil
+	assert.NoError(t, container.Close(context.Background()))
+	assert.Empty(t, log.closed)
+}
+
+func TestCloseOnlyDisposesInstancesWhenOptedIn(t *testing.T) {
+	log := &disposalLog{}
+
+	container := containertest.NewIsolated(t)
+	container.Instance(&closingDatabase{log: log})
+
+	assert.NoError(t, container.Close(context.Background()))
+	assert.Empty(t, log.closed)
+
+	container.Config.DisposeInstances = true
+	container.Instance(&closingDatabase{log: log})
+
+	// The first instance is still tracked, so it's disposed too
+	assert.NoError(t, container.Close(context.Background()))
+	assert.Equal(t, []string{"database", "database"}, log.closed)
+}
+
+func TestCloseOnlyDisposesTransientsWhenOptedIn(t *testing.T) {
+	log := &disposalLog{}
+
+	container := containertest.NewIsolated(t)
+	container.Bind(func() *closingRequest {
+		return &closingRequest{log: log}
+	})
+
+	// The caller already closed the transients they made, they aren't closed again
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, Container.MustResolve[*closingRequest](container).Close())
+	}
+	log.closed = nil
+
+	assert.NoError(t, container.Close(context.Background()))
+	assert.Empty(t, log.closed)
+
+	container.Config.DisposeTransients = true
+	Container.MustResolve[*closingRequest](container)
+
+	assert.NoError(t, container.Close(context.Background()))
+	assert.Equal(t, []string{"request"}, log.closed)
+}
+
+type closedConfig struct {
+	Name string
+}
+
+func TestCloseKeepsInstances(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Instance(&closedConfig{Name: "prod"})
+	container.Instance(map[string]int{"retries": 3})
+
+	assert.NoError(t, container.Close(context.Background()))
+
+	config, err := container.MakeE(new(closedConfig))
+	assert.NoError(t, err)
+	assert.Equal(t, &closedConfig{Name: "prod"}, config)
+
+	limits, err := container.MakeE(map[string]int{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"retries": 3}, limits)
+}
+
+func TestCloseKeepsTrackingInstancesItDidntDispose(t *testing.T) {
+	log := &disposalLog{}
+
+	container := containertest.NewIsolated(t)
+	container.Instance(&closingDatabase{log: log})
+
+	assert.NoError(t, container.Close(context.Background()))
+	assert.NoError(t, container.Close(context.Background()))
+	assert.Empty(t, log.closed)
+
+	container.Config.DisposeInstances = true
+	assert.NoError(t, container.Close(context.Background()))
+	assert.Equal(t, []string{"database"}, log.closed)
+}
+
+func TestCloseForgetsDisposedInstances(t *testing.T) {
+	log := &disposalLog{}
+
+	container := containertest.NewIsolated(t)
+	container.Config.DisposeInstances = true
+	container.Instance(&closingDatabase{log: log})
+
+	assert.NoError(t, container.Close(context.Background()))
+	assert.Equal(t, []string{"database"}, log.closed)
+
+	// The disposed instance isn't handed out again, or replaced with a zero value
+	containertest.AssertNotBound(t, container, new(closingDatabase))
+	_, err := container.MakeE(new(closingDatabase))
+	assert.ErrorIs(t, err, Container.ErrNotBound)
+}
+
+func TestClosingParentClosesChildrenFirst(t *testing.T) {
+	log := &disposalLog{}
+
+	container := containertest.NewIsolated(t)
+	container.Singleton(func() *closingDatabase {
+		return &closingDatabase{log: log}
+	})
+	container.Scoped(func() *closingRequest {
+		return &closingRequest{log: log}
+	})
+
+	child := container.CreateChildContainer()
+	Container.MustResolve[*closingDatabase](child)
+	Container.MustResolve[*closingRequest](child)
+
+	assert.NoError(t, container.Close(context.Background()))
+	assert.Equal(t, []string{"request", "database"}, log.closed)
+}
+
+func TestClosingChildLeavesParentSingletons(t *testing.T) {
+	log := &disposalLog{}
+
+	container := containertest.NewIsolated(t)
+	container.Singleton(func() *closingDatabase {
+		return &closingDatabase{log: log}
+	})
+	container.Scoped(func() *closingRequest {
+		return &closingRequest{log: log}
+	})
+
+	child := container.CreateChildContainer()
+	database := Container.MustResolve[*closingDatabase](child)
+	Container.MustResolve[*closingRequest](child)
+
+	assert.NoError(t, child.Close(context.Background()))
+	assert.Equal(t, []string{"request"}, log.closed)
+	assert.Same(t, database, Container.MustResolve[*closingDatabase](container))
+}
+
+// collectedWithin - Check the child container is garbage collected once it's no longer referenced
+func collectedWithin(createChild func() *Container.ContainerInstance, timeout time.Duration) bool {
+	collected := make(chan struct{})
+
+	child := createChild()
+	runtime.SetFinalizer(child, func(*Container.ContainerInstance) {
+		close(collected)
+	})
+	child = nil
+
+	deadline := time.After(timeout)
+	for {
+		runtime.GC()
+
+		select {
+		case <-collected:
+			return true
+		case <-deadline:
+			return false
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestUnusedChildContainersAreNotKeptByTheirParent(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Singleton(new(inventoryStore))
+	container.Bind(new(httpClient))
+
+	// Nothing is cached in the child, so it doesn't need closing
+	assert.True(t, collectedWithin(func() *Container.ContainerInstance {
+		child := container.CreateChildContainer()
+		Container.MustResolve[*inventoryStore](child)
+		Container.MustResolve[*httpClient](child)
+
+		return child
+	}, 2*time.Second))
+}
+
+func TestChildContainersHoldingInstancesAreKeptUntilClosed(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Scoped(new(httpClient))
+
+	var child *Container.ContainerInstance
+	createChild := func() *Container.ContainerInstance {
+		child = container.CreateChildContainer()
+		Container.MustResolve[*httpClient](child)
+
+		return child
+	}
+
+	// The parent keeps the child, so it can close & evict its scoped instance
+	assert.False(t, collectedWithin(func() *Container.ContainerInstance {
+		createChild()
+		retained := child
+		child = nil
+
+		return retained
+	}, 200*time.Millisecond))
+
+	assert.True(t, collectedWithin(func() *Container.ContainerInstance {
+		createChild()
+		assert.NoError(t, child.Close(context.Background()))
+		closed := child
+		child = nil
+
+		return closed
+	}, 2*time.Second))
+}
+
+func TestCloseAggregatesErrors(t *testing.T) {
+	log := &disposalLog{}
+
+	container := containertest.NewIsolated(t)
+	container.Config.DisposeTransients = true
+	container.Bind(func() *failingCloser {
+		return &failingCloser{}
+	})
+	container.Singleton(func() *closingDatabase {
+		return &closingDatabase{log: log}
+	})
+
+	Container.MustResolve[*failingCloser](container)
+	Container.MustResolve[*closingDatabase](container)
+	Container.MustResolve[*failingCloser](container)
+
+	err := container.Close(context.Background())
+
+	var closeErr *Container.CloseError
+	if assert.ErrorAs(t, err, &closeErr) {
+		assert.Len(t, closeErr.Errors, 2)
+	}
+	assert.ErrorIs(t, err, Container.ErrDisposeFailed)
+	assert.ErrorIs(t, err, errClose)
+	assert.Equal(t, []string{"database"}, log.closed)
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// ERROR RETURNING API
+//
+
+var errConstructor = errors.New("could not connect")
+
+func newFailingService() (serviceAbstract, error) {
+	return nil, errConstructor
+}
+
+func TestMakeEReturnsNotBound(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	resolved, err := container.MakeE(new(serviceAbstract))
+
+	assert.Nil(t, resolved)
+	assert.ErrorIs(t, err, Container.ErrNotBound)
+
+	var containerErr *Container.ContainerError
+	if assert.ErrorAs(t, err, &containerErr) {
+		assert.Equal(t, reflect.TypeOf((*serviceAbstract)(nil)).Elem(), containerErr.Type)
+	}
+}
+
+func TestMakeEReturnsConstructorError(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(newFailingService)
+
+	resolved, err := container.MakeE(new(serviceAbstract))
+
+	assert.Nil(t, resolved)
+	assert.ErrorIs(t, err, Container.ErrConstructorFailed)
+	assert.ErrorIs(t, err, errConstructor)
+}
+
+func TestMakeToERequiresPointer(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(newServiceConcrete)
+
+	var service *serviceConcrete
+	err := container.MakeToE(service)
+
+	assert.ErrorIs(t, err, Container.ErrInvalidTarget)
+	assert.Nil(t, service)
+
+	assert.NoError(t, container.MakeToE(&service))
+	assert.NotNil(t, service)
+}
+
+func TestCallEReturnsMissingArg(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	called := false
+	_, err := container.CallE(func(service serviceAbstract) {
+		called = true
+	})
+
+	assert.False(t, called)
+	assert.ErrorIs(t, err, Container.ErrNotBound)
+
+	_, err = container.CallE("not a function")
+	assert.ErrorIs(t, err, Container.ErrInvalidTarget)
+}
+
+func TestBindEReturnsInvalidBinding(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	assert.ErrorIs(t, container.BindE(func() {}), Container.ErrInvalidBinding)
+	assert.ErrorIs(t, container.BindE(new(serviceConcrete), newServiceConcrete), Container.ErrInvalidBinding)
+	assert.ErrorIs(t, container.SingletonE(func() {}), Container.ErrInvalidBinding)
+	assert.ErrorIs(t, container.InstanceE(nil), Container.ErrInvalidBinding)
+}
+
+func TestTaggedEReturnsFailedBinding(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(newServiceConcrete)
+	container.Bind(newFailingService)
+	container.Tag("Services", new(serviceConcrete), new(serviceAbstract))
+
+	tagged, err := container.TaggedE("Services")
+
+	assert.Len(t, tagged, 1)
+	assert.ErrorIs(t, err, Container.ErrConstructorFailed)
+}
@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// EXTENDING BINDINGS
+//
+
+type mailer interface {
+	Send() string
+}
+
+type smtpMailer struct{}
+
+func (m *smtpMailer) Send() string {
+	return "smtp"
+}
+
+type decoratedMailer struct {
+	inner  mailer
+	prefix string
+}
+
+func (m *decoratedMailer) Send() string {
+	return m.prefix + "(" + m.inner.Send() + ")"
+}
+
+func decorateMailer(prefix string) func(mailer, *Container.ContainerInstance) mailer {
+	return func(inner mailer, c *Container.ContainerInstance) mailer {
+		return &decoratedMailer{inner: inner, prefix: prefix}
+	}
+}
+
+func TestExtendersAreAppliedInRegistrationOrder(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(func() mailer { return &smtpMailer{} })
+
+	assert.True(t, container.Extend(new(mailer), decorateMailer("logging")))
+	assert.NoError(t, Container.ExtendOf[mailer](container, decorateMailer("metrics")))
+
+	assert.Equal(t, "metrics(logging(smtp))", Container.MustResolve[mailer](container).Send())
+}
+
+func TestExtendConcreteBinding(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(new(serviceConcrete))
+
+	container.Extend(new(serviceConcrete), func(service *serviceConcrete, c *Container.ContainerInstance) *serviceConcrete {
+		service.message = "extended"
+		return service
+	})
+
+	assert.Equal(t, "extended", Container.MustResolve[*serviceConcrete](container).Message())
+}
+
+func TestExtendedSingletonIsCached(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Singleton(func() mailer { return &smtpMailer{} })
+
+	calls := 0
+	container.Extend(new(mailer), func(inner mailer, c *Container.ContainerInstance) mailer {
+		calls++
+		return &decoratedMailer{inner: inner, prefix: "logging"}
+	})
+
+	first := Container.MustResolve[mailer](container)
+	assert.Same(t, first, Container.MustResolve[mailer](container))
+	assert.Equal(t, "logging(smtp)", first.Send())
+	assert.Equal(t, 1, calls)
+}
+
+func TestExtendingResolvedSingletonReappliesDecorator(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Singleton(func() mailer { return &smtpMailer{} })
+	assert.Equal(t, "smtp", Container.MustResolve[mailer](container).Send())
+
+	container.Extend(new(mailer), decorateMailer("logging"))
+
+	assert.Equal(t, "logging(smtp)", Container.MustResolve[mailer](container).Send())
+}
+
+func TestParentExtendersApplyInChildContainers(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(func() mailer { return &smtpMailer{} })
+	container.Extend(new(mailer), decorateMailer("parent"))
+
+	child := container.CreateChildContainer()
+	child.Extend(new(mailer), decorateMailer("child"))
+
+	assert.Equal(t, "child(parent(smtp))", Container.MustResolve[mailer](child).Send())
+	assert.Equal(t, "parent(smtp)", Container.MustResolve[mailer](container).Send())
+}
+
+func TestInvalidExtendersAreRejected(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	assert.ErrorIs(t, container.ExtendE(new(mailer), func(m mailer) mailer { return m }), Container.ErrInvalidBinding)
+	assert.ErrorIs(
+		t,
+		container.ExtendE(new(mailer), func(s *serviceConcrete, c *Container.ContainerInstance) *serviceConcrete { return s }),
+		Container.ErrInvalidBinding,
+	)
+	assert.ErrorIs(t, container.ExtendE(new(mailer), nil), Container.ErrInvalidBinding)
+}
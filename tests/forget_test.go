@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// FORGETTING BINDINGS & INSTANCES
+//
+
+type countedStorage struct {
+	id int
+}
+
+func (s *countedStorage) Disk() string {
+	return "counted"
+}
+
+// newCountedStorage - Returns a constructor which gives each storage the next id
+func newCountedStorage() func() storage {
+	created := 0
+
+	return func() storage {
+		created++
+		return &countedStorage{id: created}
+	}
+}
+
+func TestForgetRemovesBinding(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(new(storage), new(localStorage))
+	container.Tag("Storages", new(storage))
+
+	assert.True(t, container.Forget(new(storage)))
+
+	containertest.AssertNotBound(t, container, new(storage))
+	containertest.AssertNotBound(t, container, new(localStorage))
+	assert.Empty(t, container.Tagged("Storages"))
+
+	_, err := Container.Resolve[storage](container)
+	assert.True(t, errors.Is(err, Container.ErrNotBound))
+
+	assert.False(t, container.Forget(new(storage)))
+	assert.False(t, container.Forget(nil))
+}
+
+func TestForgetConcreteKeepsItsAbstractBinding(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(new(storage), new(localStorage))
+
+	// localStorage was only bound as the storage's concrete, it doesn't have a binding of its own
+	assert.False(t, container.Forget(new(localStorage)))
+
+	assert.Equal(t, "local", Container.MustResolve[storage](container).Disk())
+	assert.True(t, container.IsBound(new(storage)))
+}
+
+func TestForgetDropsCachedSingleton(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	newStorage := newCountedStorage()
+	container.Singleton(newStorage)
+	first := Container.MustResolve[storage](container)
+
+	assert.True(t, container.Forget(new(storage)))
+	container.Singleton(newStorage)
+
+	assert.NotSame(t, first, Container.MustResolve[storage](container))
+}
+
+func TestForgetDoesNotAffectParent(t *testing.T) {
+	parent := containertest.NewIsolated(t)
+	parent.Bind(func() storage { return &localStorage{} })
+
+	child := parent.CreateChildContainer()
+	assert.False(t, child.Forget(new(storage)))
+
+	child.Bind(func() storage { return &s3Storage{} })
+	assert.True(t, child.Forget(new(storage)))
+
+	assert.Equal(t, "local", Container.MustResolve[storage](child).Disk())
+	assert.Equal(t, "local", Container.MustResolve[storage](parent).Disk())
+}
+
+func TestForgetInstanceRebuildsSingleton(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Singleton(newCountedStorage())
+
+	assert.False(t, container.ForgetInstance(new(storage)))
+
+	first := Container.MustResolve[storage](container)
+	assert.True(t, container.ForgetInstance(new(storage)))
+
+	second := Container.MustResolve[storage](container)
+	assert.NotSame(t, first, second)
+	assert.Same(t, second, Container.MustResolve[storage](container))
+	containertest.AssertBound(t, container, new(storage))
+}
+
+func TestForgetInstanceOnlyDropsChildScopedInstance(t *testing.T) {
+	parent := containertest.NewIsolated(t)
+	parent.Scoped(newCountedStorage())
+	parentInstance := Container.MustResolve[storage](parent)
+
+	child := parent.CreateChildContainer()
+	childInstance := Container.MustResolve[storage](child)
+
+	assert.True(t, child.ForgetInstance(new(storage)))
+
+	assert.NotSame(t, childInstance, Container.MustResolve[storage](child))
+	assert.Same(t, parentInstance, Container.MustResolve[storage](parent))
+
+	// The parent's singletons are cached in the parent, so the child has nothing to forget
+	parent.Singleton(func() *countedStorage { return &countedStorage{id: 100} })
+	singleton := Container.MustResolve[*countedStorage](child)
+	assert.False(t, child.ForgetInstance(new(countedStorage)))
+	assert.Same(t, singleton, Container.MustResolve[*countedStorage](parent))
+}
+
+func TestForgetInstanceForgetsInstanceBinding(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Instance(&localStorage{})
+
+	assert.True(t, container.ForgetInstance(new(localStorage)))
+	containertest.AssertNotBound(t, container, new(localStorage))
+}
@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// GENERIC API
+//
+
+type notAService struct{}
+
+func TestResolveGeneric(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(newAnotherService)
+	container.Bind(newServiceConcrete)
+
+	service, err := Container.Resolve[anotherServiceAbstract](container)
+	assert.NoError(t, err)
+	assert.Equal(t, "Another service", service.Message())
+
+	concrete, err := Container.Resolve[*serviceConcrete](container)
+	assert.NoError(t, err)
+	assert.Equal(t, "plain service concrete", concrete.Message())
+
+	_, err = Container.Resolve[serviceAbstract](container)
+	assert.ErrorIs(t, err, Container.ErrNotBound)
+
+	assert.Panics(t, func() {
+		Container.MustResolve[serviceAbstract](container)
+	})
+}
+
+func TestBindToGeneric(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	assert.NoError(t, Container.BindTo[serviceAbstract, serviceConcrete](container))
+	assert.ErrorIs(t, Container.BindTo[serviceAbstract, notAService](container), Container.ErrInvalidBinding)
+
+	service := Container.MustResolve[serviceAbstract](container)
+	assert.Equal(t, "Hello World!", service.Message())
+
+	// The generic and reflective api share the same bindings
+	containertest.AssertBound(t, container, new(serviceAbstract))
+}
+
+func TestSingletonOfAndInstanceOfGeneric(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	assert.NoError(t, Container.SingletonOf[*serviceConcrete](container, createSingletonServiceOne))
+	first := Container.MustResolve[*serviceConcrete](container)
+	second := Container.MustResolve[*serviceConcrete](container)
+	assert.Same(t, first, second)
+
+	instance := &serviceConcreteTwo{message: "bound instance"}
+	assert.NoError(t, Container.InstanceOf[anotherServiceAbstract](container, instance))
+
+	resolved := Container.MustResolve[anotherServiceAbstract](container)
+	assert.Same(t, instance, resolved)
+}
+
+func TestTaggedOfAndCallTypedGeneric(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(newServiceConcrete)
+	container.Bind(newAnotherService)
+	container.Tag("Services", new(serviceConcrete), new(anotherServiceAbstract))
+
+	services, err := Container.TaggedOf[serviceAbstract](container, "Services")
+	assert.NoError(t, err)
+	assert.Len(t, services, 2)
+
+	_, err = Container.TaggedOf[*serviceConcreteTwo](container, "Services")
+	assert.ErrorIs(t, err, Container.ErrInvalidTarget)
+
+	message, err := Container.CallTyped[string](container, func(service anotherServiceAbstract) string {
+		return service.Message()
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Another service", message)
+
+	_, err = Container.CallTyped[string](container, func() (string, error) {
+		return "", errConstructor
+	})
+	assert.ErrorIs(t, err, errConstructor)
+}
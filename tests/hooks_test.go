@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// RESOLVING HOOKS
+//
+
+func TestResolvingHooksOrder(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(func() mailer { return &smtpMailer{} })
+	container.Bind(new(serviceConcrete))
+
+	var calls []string
+	container.AfterResolving(new(mailer), func(m mailer, c *Container.ContainerInstance) {
+		calls = append(calls, "after:"+m.Send())
+	})
+	container.Resolving(new(mailer), func(m mailer, c *Container.ContainerInstance) {
+		calls = append(calls, "resolving:"+m.Send())
+	})
+	container.ResolvingAny(func(instance any, c *Container.ContainerInstance) {
+		calls = append(calls, "any")
+	})
+	container.Extend(new(mailer), decorateMailer("logging"))
+
+	Container.MustResolve[mailer](container)
+	assert.Equal(t, []string{"any", "resolving:logging(smtp)", "after:logging(smtp)"}, calls)
+
+	calls = nil
+	Container.MustResolve[*serviceConcrete](container)
+	assert.Equal(t, []string{"any"}, calls)
+}
+
+func TestResolvingHookCanSetupInstance(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(new(serviceConcrete))
+
+	assert.NoError(t, Container.ResolvingOf[*serviceConcrete](container, func(service *serviceConcrete, c *Container.ContainerInstance) {
+		service.message = "configured"
+	}))
+
+	assert.Equal(t, "configured", Container.MustResolve[*serviceConcrete](container).Message())
+}
+
+func TestResolvingHooksOnlyFireWhenSingletonIsCreated(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Singleton(func() mailer { return &smtpMailer{} })
+
+	calls := 0
+	Container.AfterResolvingOf[mailer](container, func(m mailer, c *Container.ContainerInstance) {
+		calls++
+	})
+
+	Container.MustResolve[mailer](container)
+	Container.MustResolve[mailer](container)
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestParentHooksFireForChildResolutions(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(func() mailer { return &smtpMailer{} })
+
+	var resolvedBy []*Container.ContainerInstance
+	container.Resolving(new(mailer), func(m mailer, c *Container.ContainerInstance) {
+		resolvedBy = append(resolvedBy, c)
+	})
+
+	child := container.CreateChildContainer()
+	childCalls := 0
+	child.AfterResolvingAny(func(instance any, c *Container.ContainerInstance) {
+		childCalls++
+	})
+
+	Container.MustResolve[mailer](child)
+	Container.MustResolve[mailer](container)
+
+	assert.Equal(t, []*Container.ContainerInstance{child, container}, resolvedBy)
+	assert.Equal(t, 1, childCalls)
+}
+
+func TestResolvingHookErrorFailsResolution(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(func() mailer { return &smtpMailer{} })
+
+	errMissingConfig := errors.New("missing mail config")
+	container.Resolving(new(mailer), func(m mailer, c *Container.ContainerInstance) error {
+		return errMissingConfig
+	})
+
+	_, err := Container.Resolve[mailer](container)
+
+	assert.ErrorIs(t, err, Container.ErrConstructorFailed)
+	assert.ErrorIs(t, err, errMissingConfig)
+}
+
+func TestInvalidResolvingHooksAreRejected(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	assert.ErrorIs(t, container.ResolvingE(new(mailer), func(m mailer) {}), Container.ErrInvalidBinding)
+	assert.ErrorIs(
+		t,
+		container.AfterResolvingE(new(mailer), func(s *serviceConcrete, c *Container.ContainerInstance) {}),
+		Container.ErrInvalidBinding,
+	)
+	assert.ErrorIs(
+		t,
+		container.ResolvingE(new(mailer), func(m mailer, c *Container.ContainerInstance) string { return "" }),
+		Container.ErrInvalidBinding,
+	)
+}
@@ -0,0 +1,187 @@
+package tests
+
+import (
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// CHECKING CONCRETES IMPLEMENT THEIR ABSTRACTS WHEN THEY'RE REGISTERED
+//
+
+type notifier interface {
+	Notify(message string) error
+	Channel() string
+}
+
+// Notify has a pointer receiver, so only *emailNotifier is a notifier
+type emailNotifier struct {
+	sent []string
+}
+
+func (n *emailNotifier) Notify(message string) error {
+	n.sent = append(n.sent, message)
+	return nil
+}
+
+func (n *emailNotifier) Channel() string {
+	return "email"
+}
+
+// smsNotifier is missing Channel
+type smsNotifier struct{}
+
+func (n smsNotifier) Notify(message string) error {
+	return nil
+}
+
+// pagerNotifier has Notify with the wrong signature
+type pagerNotifier struct{}
+
+func (n pagerNotifier) Notify(message string) {}
+
+func (n pagerNotifier) Channel() string {
+	return "pager"
+}
+
+func TestBindRejectsConcreteMissingMethods(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	err := container.BindE(new(notifier), smsNotifier{})
+	assert.ErrorIs(t, err, Container.ErrInvalidBinding)
+	assert.Contains(t, err.Error(), "doesn't implement tests.notifier: missing method Channel")
+
+	err = container.BindE(new(notifier), pagerNotifier{})
+	assert.ErrorIs(t, err, Container.ErrInvalidBinding)
+	assert.Contains(t, err.Error(), "method Notify is func(string), it should be func(string) error")
+
+	err = container.BindE(new(notifier), &wrongService{})
+	assert.ErrorIs(t, err, Container.ErrInvalidBinding)
+	assert.Contains(t, err.Error(), "missing method Channel, missing method Notify")
+
+	containertest.AssertNotBound(t, container, new(notifier))
+}
+
+func TestBindAcceptsStructWithPointerReceivers(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	// The container instantiates a *emailNotifier, so the pointer receivers are fine
+	assert.NoError(t, container.BindE(new(notifier), emailNotifier{}))
+	assert.NoError(t, Container.BindTo[notifier, emailNotifier](container))
+
+	notifier := containertest.AssertResolvesTo[*emailNotifier](t, container, new(notifier))
+	assert.Equal(t, "email", notifier.Channel())
+}
+
+func TestBindRejectsResolverReturningValueWithPointerReceivers(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	newEmailNotifier := func() emailNotifier { return emailNotifier{} }
+
+	err := container.BindE(new(notifier), newEmailNotifier)
+	assert.ErrorIs(t, err, Container.ErrInvalidBinding)
+	assert.Contains(t, err.Error(), "method Notify has a pointer receiver, use *tests.emailNotifier")
+
+	err = container.SingletonE(new(notifier), newEmailNotifier)
+	assert.ErrorIs(t, err, Container.ErrInvalidBinding)
+
+	err = container.ScopedE(new(notifier), newEmailNotifier)
+	assert.ErrorIs(t, err, Container.ErrInvalidBinding)
+
+	containertest.AssertNotBound(t, container, new(notifier))
+}
+
+func TestSingletonRejectsResolverForAnotherType(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	err := container.SingletonE(new(serviceConcrete), func() *emailNotifier { return &emailNotifier{} })
+	assert.ErrorIs(t, err, Container.ErrInvalidBinding)
+	assert.Contains(t, err.Error(), "can't be used as tests.serviceConcrete")
+
+	containertest.AssertNotBound(t, container, new(serviceConcrete))
+}
+
+func TestNamedAndTaggedRegistrationsAreChecked(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	assert.ErrorIs(t, container.BindNamedE("sms", new(notifier), smsNotifier{}), Container.ErrInvalidBinding)
+	assert.ErrorIs(t, container.SingletonNamedE("sms", new(notifier), smsNotifier{}), Container.ErrInvalidBinding)
+	assert.ErrorIs(t, container.BindManyE(new(notifier), new(emailNotifier), smsNotifier{}), Container.ErrInvalidBinding)
+	assert.ErrorIs(t, container.When(new(serviceConcrete)).Needs(new(notifier)).GiveE(smsNotifier{}), Container.ErrInvalidBinding)
+
+	_, err := container.SwapE(new(notifier), emailNotifier{})
+	assert.ErrorIs(t, err, Container.ErrInvalidBinding)
+	assert.Contains(t, err.Error(), "method Notify has a pointer receiver")
+
+	assert.NoError(t, container.BindNamedE("email", new(notifier), emailNotifier{}))
+	assert.NoError(t, container.BindManyE(new(notifier), new(emailNotifier)))
+}
+
+// hiddenSecret only has an unexported method, which reflection can't list on concrete types
+type hiddenSecret interface {
+	secret() string
+}
+
+type secretKeeper struct {
+	value string
+}
+
+func (k secretKeeper) secret() string {
+	return k.value
+}
+
+func TestBindAcceptsInterfaceWithUnexportedMethods(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	assert.NoError(t, container.BindE(new(hiddenSecret), secretKeeper{}))
+	assert.NoError(t, container.BindE(new(hiddenSecret), func() *secretKeeper { return &secretKeeper{value: "ptr"} }))
+	assert.NoError(t, container.SingletonE(new(hiddenSecret), func() secretKeeper { return secretKeeper{value: "value"} }))
+	assert.NoError(t, Container.BindTo[hiddenSecret, secretKeeper](container))
+	assert.NoError(t, container.BindManyE(new(hiddenSecret), secretKeeper{}))
+	assert.NoError(t, container.When(new(serviceConcrete)).Needs(new(hiddenSecret)).GiveE(secretKeeper{}))
+
+	restore, err := container.SwapE(new(hiddenSecret), secretKeeper{value: "fake"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "fake", Container.MustResolve[hiddenSecret](container).secret())
+		restore()
+	}
+
+	// A type without the unexported method is still rejected
+	err = container.BindE(new(hiddenSecret), smsNotifier{})
+	assert.ErrorIs(t, err, Container.ErrInvalidBinding)
+	assert.Contains(t, err.Error(), "doesn't implement tests.hiddenSecret: missing method secret")
+}
+
+// newAnyService - Returns an interface, so it's only known to implement serviceAbstract once it's called
+func newAnyService() any {
+	return newServiceConcrete()
+}
+
+func TestResolverReturningInterfaceIsCheckedWhenResolved(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	// Every registration path accepts it, the same as Bind
+	assert.NoError(t, container.BindE(new(serviceAbstract), newAnyService))
+	assert.NoError(t, container.SingletonE(new(serviceAbstract), newAnyService))
+	assert.NoError(t, container.BindManyE(new(serviceAbstract), newAnyService))
+	assert.NoError(t, container.When(new(serviceConcrete)).Needs(new(serviceAbstract)).GiveE(newAnyService))
+
+	assert.Equal(t, "plain service concrete", Container.MustResolve[serviceAbstract](container).Message())
+	assert.Len(t, Container.MustResolve[[]serviceAbstract](container), 1)
+
+	restore, err := container.SwapE(new(serviceAbstract), func() storage { return &wrongService{} })
+	if assert.NoError(t, err) {
+		_, err = Container.Resolve[serviceAbstract](container)
+		assert.ErrorIs(t, err, Container.ErrInvalidBinding)
+		assert.Contains(t, err.Error(), "which doesn't implement tests.serviceAbstract")
+		restore()
+	}
+
+	// What it returns is still checked
+	assert.NoError(t, container.SingletonE(new(notifier), newAnyService))
+	_, err = Container.Resolve[notifier](container)
+	assert.ErrorIs(t, err, Container.ErrInvalidBinding)
+}
@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// INJECT TAGS
+//
+
+type taggedFieldsService struct {
+	Service  serviceAbstract        `inject:""`
+	Skipped  anotherServiceAbstract `inject:"-"`
+	Optional *requestContext        `inject:"optional"`
+	Replica  database               `inject:"name=replica"`
+	Stats    []serviceAbstract      `inject:"tagged=StatServices"`
+	Untagged anotherServiceAbstract
+}
+
+type requiredFieldService struct {
+	Service serviceAbstract `inject:""`
+}
+
+type optionalNamedFieldService struct {
+	Replica database `inject:"name=replica,optional"`
+}
+
+func bindTaggedFieldsServices(container *Container.ContainerInstance) {
+	container.Bind(newServiceConcreteTwo)
+	container.Bind(newAnotherService)
+	container.BindNamed("replica", newReplicaDatabase)
+	container.Bind(func() serviceAbstract { return newServiceConcrete() })
+	container.Tag("StatServices", new(serviceAbstract), new(serviceConcreteTwo))
+	container.Bind(new(taggedFieldsService))
+}
+
+func TestInjectTagGrammar(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	bindTaggedFieldsServices(container)
+
+	service, err := Container.Resolve[*taggedFieldsService](container)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "plain service concrete", service.Service.Message())
+	assert.Nil(t, service.Skipped)
+	assert.Nil(t, service.Optional)
+	assert.Equal(t, "replica", service.Replica.Host())
+	assert.Len(t, service.Stats, 2)
+	assert.NotNil(t, service.Untagged)
+}
+
+func TestOnlyInjectStructFieldsWithInjectTag(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Config.OnlyInjectStructFieldsWithInjectTag = true
+	bindTaggedFieldsServices(container)
+	container.Scoped(new(requestContext))
+
+	service, err := Container.Resolve[*taggedFieldsService](container)
+	assert.NoError(t, err)
+
+	assert.NotNil(t, service.Service)
+	assert.NotNil(t, service.Optional)
+	assert.Equal(t, "replica", service.Replica.Host())
+	assert.Len(t, service.Stats, 2)
+	assert.Nil(t, service.Skipped)
+	assert.Nil(t, service.Untagged)
+}
+
+func TestTaggedFieldIsRequired(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(new(requiredFieldService))
+
+	_, err := Container.Resolve[*requiredFieldService](container)
+
+	assert.ErrorIs(t, err, Container.ErrNotBound)
+	assert.Contains(t, err.Error(), "field Service: tests.serviceAbstract(iface) (not bound)")
+}
+
+func TestOptionalNamedFieldIsSkipped(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(new(optionalNamedFieldService))
+
+	service, err := Container.Resolve[*optionalNamedFieldService](container)
+	assert.NoError(t, err)
+	assert.Nil(t, service.Replica)
+}
+
+func TestMalformedInjectTagsAreRejected(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	malformed := []any{
+		new(struct {
+			Service serviceAbstract `inject:"optional,"`
+		}),
+		new(struct {
+			Service serviceAbstract `inject:"-,optional"`
+		}),
+		new(struct {
+			Service serviceAbstract `inject:"name="`
+		}),
+		new(struct {
+			Service serviceAbstract `inject:"lazy=true"`
+		}),
+		new(struct {
+			Service serviceAbstract `inject:"name=a,name=b"`
+		}),
+		new(struct {
+			Services []serviceAbstract `inject:"name=a,tagged=b"`
+		}),
+		new(struct {
+			Service serviceAbstract `inject:"tagged=StatServices"`
+		}),
+	}
+
+	for _, definition := range malformed {
+		err := container.BindE(definition)
+
+		assert.ErrorIs(t, err, Container.ErrInvalidBinding)
+		assert.ErrorIs(t, err, Container.ErrInvalidTag)
+	}
+}
+
+func TestMalformedInjectTagFailsResolve(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(newServiceConcreteTwo)
+
+	invocable := Container.CreateInvocableStruct(&struct {
+		Service *serviceConcreteTwo `inject:"unknown=option"`
+	}{})
+
+	assert.Nil(t, invocable.InstantiateStructAndFill(container).Elem().Field(0).Interface())
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -13,7 +14,7 @@ import (
 //
 
 func TestCallingFunctionUsingDI(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	container.Bind(createSingletonServiceOne)
 
 	container.Call(func(concrete *serviceConcrete) {
@@ -24,7 +25,7 @@ func TestCallingFunctionUsingDI(t *testing.T) {
 }
 
 func TestCallingFunctionOnStructUsingDI(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	container.Bind(createSingletonServiceOne)
 
 	invocable := Container.CreateInvocableStruct(reflect.ValueOf(&serviceConcrete{}))
@@ -38,17 +39,13 @@ func TestCreatingStructUsingDI(t *testing.T) {
 	type TestStruct struct {
 		Resolved anotherServiceAbstract
 	}
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 
 	container.Bind(newAnotherService)
-	if !container.IsBound(new(anotherServiceAbstract)) {
-		t.Fatal("Could not find anotherServiceAbstract binding in container")
-	}
+	containertest.AssertBound(t, container, new(anotherServiceAbstract))
 
 	container.Bind(new(TestStruct))
-	if !container.IsBound(new(TestStruct)) {
-		t.Fatal("Could not find TestStruct binding in container")
-	}
+	containertest.AssertBound(t, container, new(TestStruct))
 
 	var service *TestStruct
 	container.MakeTo(&service)
@@ -64,7 +61,7 @@ func TestCreatingStructUsingDI(t *testing.T) {
 }
 
 func TestCallingFunctionOnStructUsingArgInterceptor(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	container.Bind(createSingletonServiceOne)
 
 	invocable := Container.CreateInvocableStruct(reflect.ValueOf(&serviceConcrete{}))
@@ -0,0 +1,223 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// LAZY & PROVIDER INJECTION
+//
+
+type lazyReport struct {
+	Service Container.Lazy[serviceAbstract]
+}
+
+type lazyParent struct {
+	Child Container.Lazy[*lazyChild]
+}
+
+type lazyChild struct {
+	Parent *lazyParent
+}
+
+type providedReport struct {
+	NewService func() serviceAbstract
+}
+
+func TestLazyFieldResolvesOnFirstUse(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	created := 0
+	container.Bind(func() serviceAbstract {
+		created++
+		return newServiceConcrete()
+	})
+	container.Bind(new(lazyReport))
+
+	report := Container.MustResolve[*lazyReport](container)
+	assert.Equal(t, 0, created)
+
+	first := report.Service.Get()
+	assert.Equal(t, "plain service concrete", first.Message())
+	assert.Same(t, first, report.Service.Get())
+	assert.Equal(t, 1, created)
+}
+
+func TestLazyArgResolvesOnFirstUse(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(newFailingService)
+
+	var lazy Container.Lazy[serviceAbstract]
+	container.Call(func(service Container.Lazy[serviceAbstract]) {
+		lazy = service
+	})
+
+	_, err := lazy.GetE()
+	assert.ErrorIs(t, err, errConstructor)
+
+	// Failures aren't cached, so binding a working constructor lets the next Get succeed
+	container.Bind(func() serviceAbstract { return newServiceConcrete() })
+
+	service, err := lazy.GetE()
+	assert.NoError(t, err)
+	assert.Equal(t, "plain service concrete", service.Message())
+}
+
+func TestZeroLazyReturnsInvalidTarget(t *testing.T) {
+	var lazy Container.Lazy[serviceAbstract]
+
+	_, err := lazy.GetE()
+	assert.ErrorIs(t, err, Container.ErrInvalidTarget)
+	assert.Nil(t, lazy.Get())
+}
+
+func TestLazyBreaksConstructionCycle(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Singleton(new(lazyParent))
+	container.Bind(new(lazyChild))
+
+	parent, err := Container.Resolve[*lazyParent](container)
+	assert.NoError(t, err)
+
+	child := parent.Child.Get()
+	assert.Same(t, parent, child.Parent)
+}
+
+func TestProviderResolvesOnEveryCall(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	created := 0
+	container.Bind(func() serviceAbstract {
+		created++
+		return newServiceConcrete()
+	})
+	container.Bind(new(providedReport))
+
+	report := Container.MustResolve[*providedReport](container)
+	assert.Equal(t, 0, created)
+
+	assert.NotSame(t, report.NewService(), report.NewService())
+	assert.Equal(t, 2, created)
+}
+
+func TestProviderWithErrorReturnsConstructorError(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(newFailingService)
+
+	results, err := container.CallE(func(provide func() (serviceAbstract, error)) error {
+		_, err := provide()
+		return err
+	})
+
+	assert.NoError(t, err)
+	assert.ErrorIs(t, results[0].(error), Container.ErrConstructorFailed)
+	assert.ErrorIs(t, results[0].(error), errConstructor)
+}
+
+func TestProviderOfUnboundTypeIsNotInjected(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	_, err := container.CallE(func(provide func() serviceAbstract) {})
+
+	assert.ErrorIs(t, err, Container.ErrNotBound)
+}
+
+type selfReferencing struct {
+	attempts int
+}
+
+// resolveWithin - Resolve T in a goroutine, failing the test rather than hanging if it never returns
+func resolveWithin[T any](t *testing.T, container *Container.ContainerInstance, timeout time.Duration) error {
+	t.Helper()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := Container.Resolve[T](container)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		t.Fatalf("resolving %T never returned", *new(T))
+		return nil
+	}
+}
+
+func TestLazyUsedBySingletonsOwnConstructorReturnsDeadlock(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	var lazyErr error
+	container.Singleton(func(self Container.Lazy[*selfReferencing]) *selfReferencing {
+		_, lazyErr = self.GetE()
+		return &selfReferencing{}
+	})
+
+	assert.NoError(t, resolveWithin[*selfReferencing](t, container, 2*time.Second))
+	assert.ErrorIs(t, lazyErr, Container.ErrDeadlock)
+
+	// Once it's constructed, the Lazy resolves the singleton as usual
+	assert.NotNil(t, Container.MustResolve[*selfReferencing](container))
+}
+
+func TestProviderUsedBySingletonsOwnConstructorReturnsDeadlock(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	var providerErr error
+	container.Singleton(func(self func() (*selfReferencing, error)) *selfReferencing {
+		_, providerErr = self()
+		return &selfReferencing{}
+	})
+
+	assert.NoError(t, resolveWithin[*selfReferencing](t, container, 2*time.Second))
+	assert.ErrorIs(t, providerErr, Container.ErrDeadlock)
+}
+
+type backgroundStarter struct{}
+
+type backgroundTarget struct {
+	starter *backgroundStarter
+}
+
+func TestLazyUsedFromAnotherGoroutineWaitsForSingleton(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	started := make(chan struct{})
+	used := make(chan error, 1)
+	var target *backgroundTarget
+
+	// The starter's constructor hands the Lazy to a goroutine, which uses it while the target is still being constructed
+	container.Singleton(func(lazyTarget Container.Lazy[*backgroundTarget]) *backgroundStarter {
+		go func() {
+			close(started)
+
+			var err error
+			target, err = lazyTarget.GetE()
+			used <- err
+		}()
+
+		return &backgroundStarter{}
+	})
+	container.Singleton(func(starter *backgroundStarter) *backgroundTarget {
+		<-started
+		time.Sleep(50 * time.Millisecond)
+
+		return &backgroundTarget{starter: starter}
+	})
+
+	assert.NoError(t, resolveWithin[*backgroundTarget](t, container, 2*time.Second))
+
+	select {
+	case err := <-used:
+		assert.NoError(t, err)
+		assert.Same(t, Container.MustResolve[*backgroundTarget](container), target)
+	case <-time.After(2 * time.Second):
+		t.Fatal("the goroutine using the Lazy never returned")
+	}
+}
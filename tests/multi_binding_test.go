@@ -0,0 +1,174 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// MULTI BINDINGS
+//
+
+type healthCheck interface {
+	Name() string
+}
+
+type databaseCheck struct {
+	attempts int
+}
+
+func (c *databaseCheck) Name() string {
+	return "database"
+}
+
+type cacheCheck struct {
+	attempts int
+}
+
+func (c *cacheCheck) Name() string {
+	return "cache"
+}
+
+type diskCheck struct {
+	attempts int
+}
+
+func (c *diskCheck) Name() string {
+	return "disk"
+}
+
+type healthController struct {
+	checks []healthCheck
+}
+
+func newHealthController(checks []healthCheck) *healthController {
+	return &healthController{checks: checks}
+}
+
+type healthReport struct {
+	Checks []healthCheck
+}
+
+func checkNames(checks []healthCheck) []string {
+	names := make([]string, len(checks))
+	for i, check := range checks {
+		names[i] = check.Name()
+	}
+
+	return names
+}
+
+func TestBindManyInjectsConstructorArgs(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	assert.True(t, container.BindMany(new(healthCheck), func() healthCheck { return &databaseCheck{attempts: 3} }, new(cacheCheck)))
+	assert.True(t, container.Append(new(healthCheck), new(diskCheck)))
+	container.Bind(newHealthController)
+
+	controller := Container.MustResolve[*healthController](container)
+
+	assert.Equal(t, []string{"database", "cache", "disk"}, checkNames(controller.checks))
+}
+
+func TestBindManyInjectsStructFieldsAndCallArgs(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.BindMany(new(healthCheck), new(databaseCheck), new(cacheCheck))
+	container.Bind(new(healthReport))
+
+	report := Container.MustResolve[*healthReport](container)
+	assert.Equal(t, []string{"database", "cache"}, checkNames(report.Checks))
+
+	results := container.Call(func(checks []healthCheck) []string {
+		return checkNames(checks)
+	})
+	assert.Equal(t, []string{"database", "cache"}, results[0])
+}
+
+func TestBindManyResolvesSlice(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.BindMany(new(healthCheck), new(databaseCheck), new(cacheCheck))
+
+	checks, err := Container.Resolve[[]healthCheck](container)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"database", "cache"}, checkNames(checks))
+
+	var made []healthCheck
+	assert.NoError(t, container.MakeToE(&made))
+	assert.Equal(t, []string{"database", "cache"}, checkNames(made))
+}
+
+func TestBindManyInstancesAreTransient(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.BindMany(new(healthCheck), new(databaseCheck))
+
+	first := Container.MustResolve[[]healthCheck](container)
+	second := Container.MustResolve[[]healthCheck](container)
+
+	assert.NotSame(t, first[0], second[0])
+}
+
+func TestBindManyReplacesGroup(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.BindMany(new(healthCheck), new(databaseCheck), new(cacheCheck))
+	container.BindMany(new(healthCheck), new(diskCheck))
+
+	assert.Equal(t, []string{"disk"}, checkNames(Container.MustResolve[[]healthCheck](container)))
+}
+
+func TestBindManyEmptyGroup(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	_, err := Container.Resolve[[]healthCheck](container)
+	assert.True(t, errors.Is(err, Container.ErrNotBound))
+
+	assert.True(t, container.BindMany(new(healthCheck)))
+	container.Bind(newHealthController)
+
+	controller := Container.MustResolve[*healthController](container)
+	assert.NotNil(t, controller.checks)
+	assert.Empty(t, controller.checks)
+}
+
+func TestBindManyAggregatesParentAndChild(t *testing.T) {
+	parent := containertest.NewIsolated(t)
+	parent.BindMany(new(healthCheck), new(databaseCheck))
+	parent.Bind(newHealthController)
+
+	child := parent.CreateChildContainer()
+	child.Append(new(healthCheck), new(diskCheck))
+
+	assert.Equal(t, []string{"database", "disk"}, checkNames(Container.MustResolve[*healthController](child).checks))
+	assert.Equal(t, []string{"database"}, checkNames(Container.MustResolve[*healthController](parent).checks))
+}
+
+func TestBindManyFunctionReturningSlice(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.BindMany(new(healthCheck), new(databaseCheck))
+
+	// A function returning the slice is a regular binding, and wins over the group
+	container.Bind(func() []healthCheck { return []healthCheck{&cacheCheck{}} })
+
+	assert.Equal(t, []string{"cache"}, checkNames(Container.MustResolve[[]healthCheck](container)))
+	containertest.AssertNotBound(t, container, new(healthCheck))
+}
+
+func TestBindManyRejectsInvalidConcrete(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	err := container.BindManyE(new(healthCheck), new(databaseCheck), new(serviceConcrete))
+	assert.True(t, errors.Is(err, Container.ErrInvalidBinding))
+
+	// Nothing from the failed BindMany was registered
+	_, err = Container.Resolve[[]healthCheck](container)
+	assert.True(t, errors.Is(err, Container.ErrNotBound))
+
+	// A function returning another interface is only checked when it's resolved, the same as Bind
+	assert.NoError(t, container.AppendE(new(healthCheck), func() storage { return &localStorage{} }))
+
+	_, err = Container.Resolve[[]healthCheck](container)
+	assert.True(t, errors.Is(err, Container.ErrInvalidBinding))
+}
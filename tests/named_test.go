@@ -0,0 +1,138 @@
+package tests
+
+import (
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// NAMED BINDINGS
+//
+
+type database interface {
+	Host() string
+}
+
+type hostDatabase struct {
+	host string
+}
+
+func (d *hostDatabase) Host() string {
+	return d.host
+}
+
+func newPrimaryDatabase() database {
+	return &hostDatabase{host: "primary"}
+}
+
+func newReplicaDatabase() database {
+	return &hostDatabase{host: "replica"}
+}
+
+type databaseReport struct {
+	Primary database
+	Replica database `inject:"replica"`
+}
+
+type replicaReport struct {
+	replica database
+}
+
+func newReplicaReport(replica database) *replicaReport {
+	return &replicaReport{replica: replica}
+}
+
+func bindDatabases(container *Container.ContainerInstance) {
+	container.Bind(newPrimaryDatabase)
+	container.BindNamed("replica", newReplicaDatabase)
+	container.SingletonNamed("analytics", func() database {
+		return &hostDatabase{host: "analytics"}
+	})
+}
+
+func TestNamedBindingsDontOverwriteEachOther(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	bindDatabases(container)
+
+	assert.Equal(t, "primary", Container.MustResolve[database](container).Host())
+	assert.Equal(t, "replica", container.MakeNamed("replica", new(database)).(database).Host())
+
+	analytics, err := Container.ResolveNamed[database](container, "analytics")
+	assert.NoError(t, err)
+	assert.Equal(t, "analytics", analytics.Host())
+	assert.Same(t, analytics, container.MakeNamed("analytics", new(database)))
+
+	assert.True(t, container.IsBoundNamed("replica", new(database)))
+	assert.False(t, container.IsBoundNamed("missing", new(database)))
+}
+
+func TestMakeNamedReturnsNotBound(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(newPrimaryDatabase)
+
+	_, err := container.MakeNamedE("replica", new(database))
+
+	assert.ErrorIs(t, err, Container.ErrNotBound)
+	assert.Contains(t, err.Error(), `"replica" tests.database(iface) (not bound)`)
+}
+
+func TestNamedBindingsAreFoundInParent(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	bindDatabases(container)
+
+	child := container.CreateChildContainer()
+	child.BindNamed("replica", func() database {
+		return &hostDatabase{host: "child replica"}
+	})
+
+	assert.Equal(t, "child replica", child.MakeNamed("replica", new(database)).(database).Host())
+	assert.Equal(t, "replica", container.MakeNamed("replica", new(database)).(database).Host())
+	assert.Same(t, container.MakeNamed("analytics", new(database)), child.MakeNamed("analytics", new(database)))
+}
+
+func TestNamedStructTagInjection(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	bindDatabases(container)
+	container.Bind(new(databaseReport))
+
+	report := Container.MustResolve[*databaseReport](container)
+
+	assert.Equal(t, "primary", report.Primary.Host())
+	assert.Equal(t, "replica", report.Replica.Host())
+}
+
+func TestNamedArgsAtCallTime(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	bindDatabases(container)
+
+	hosts, err := Container.CallTyped[[]string](container, func(primary database, replica database) []string {
+		return []string{primary.Host(), replica.Host()}
+	}, Container.NamedArgs{1: "replica"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"primary", "replica"}, hosts)
+}
+
+func TestNamedArgsAtBindTime(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	bindDatabases(container)
+	container.Bind(newReplicaReport, Container.NamedArgs{0: "replica"})
+
+	report := Container.MustResolve[*replicaReport](container)
+	assert.Equal(t, "replica", report.replica.Host())
+
+	// NamedArgs given to Make take priority over the ones it was bound with
+	report = Container.MustResolve[*replicaReport](container, Container.NamedArgs{0: "analytics"})
+	assert.Equal(t, "analytics", report.replica.Host())
+}
+
+func TestInvalidNamedArgsAreRejected(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	assert.ErrorIs(t, container.BindE(newReplicaReport, Container.NamedArgs{1: "replica"}), Container.ErrInvalidBinding)
+	assert.ErrorIs(t, container.BindE(new(databaseReport), Container.NamedArgs{0: "replica"}), Container.ErrInvalidBinding)
+	assert.ErrorIs(t, container.BindNamedE("", newReplicaDatabase), Container.ErrInvalidBinding)
+}
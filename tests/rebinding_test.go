@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// REBINDING
+//
+
+type credentials struct {
+	key string
+}
+
+type apiClient struct {
+	credentials *credentials
+}
+
+func (c *apiClient) SetCredentials(credentials *credentials) {
+	c.credentials = credentials
+}
+
+func TestRebindingCallbackReceivesNewInstance(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Instance(&credentials{key: "old"})
+
+	var rebound []string
+	assert.NoError(t, Container.RebindingOf[*credentials](container, func(c *Container.ContainerInstance, creds *credentials) {
+		rebound = append(rebound, creds.key)
+	}))
+
+	container.Instance(&credentials{key: "new"})
+	container.Singleton(func() *credentials { return &credentials{key: "rotated"} })
+
+	assert.Equal(t, []string{"new", "rotated"}, rebound)
+}
+
+func TestRebindingIsNotCalledForFirstBinding(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	calls := 0
+	container.Rebinding(new(credentials), func(c *Container.ContainerInstance, creds *credentials) {
+		calls++
+	})
+	container.Instance(&credentials{key: "first"})
+
+	assert.Equal(t, 0, calls)
+}
+
+func TestRefreshCallsSetterOnTarget(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Instance(&credentials{key: "old"})
+
+	client := &apiClient{}
+	client.SetCredentials(container.Refresh(new(credentials), client, "SetCredentials").(*credentials))
+	assert.Equal(t, "old", client.credentials.key)
+
+	container.Instance(&credentials{key: "new"})
+	assert.Equal(t, "new", client.credentials.key)
+}
+
+func TestRefreshRequiresSetter(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Instance(&credentials{key: "old"})
+
+	_, err := container.RefreshE(new(credentials), &apiClient{}, "Missing")
+	assert.ErrorIs(t, err, Container.ErrInvalidTarget)
+}
+
+func TestRebindEvictsCachedSingletonInChildren(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Scoped(func() *credentials { return &credentials{key: "old"} })
+
+	child := container.CreateChildContainer()
+	grandchild := child.CreateChildContainer()
+	assert.Equal(t, "old", Container.MustResolve[*credentials](child).key)
+	assert.Equal(t, "old", Container.MustResolve[*credentials](grandchild).key)
+
+	container.Scoped(func() *credentials { return &credentials{key: "new"} })
+
+	assert.Equal(t, "new", Container.MustResolve[*credentials](container).key)
+	assert.Equal(t, "new", Container.MustResolve[*credentials](child).key)
+	assert.Equal(t, "new", Container.MustResolve[*credentials](grandchild).key)
+}
+
+func TestInvalidRebindingCallbacksAreRejected(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	assert.ErrorIs(t, container.RebindingE(new(credentials), func(creds *credentials) {}), Container.ErrInvalidBinding)
+	assert.ErrorIs(
+		t,
+		container.RebindingE(new(credentials), func(c *Container.ContainerInstance, s *serviceConcrete) {}),
+		Container.ErrInvalidBinding,
+	)
+}
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// RESOLUTION ERROR CONTEXT
+//
+
+type chainTop struct {
+	Middle chainMiddle
+}
+
+type chainMiddle interface {
+	Name() string
+}
+
+type chainMiddleImpl struct{}
+
+func (m *chainMiddleImpl) Name() string {
+	return "middle"
+}
+
+type chainLeaf interface {
+	Leaf()
+}
+
+func newChainMiddle(service anotherServiceAbstract, leaf chainLeaf) chainMiddle {
+	return &chainMiddleImpl{}
+}
+
+func TestResolutionErrorHoldsFullPath(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(new(chainTop))
+	container.Bind(newChainMiddle)
+	container.Bind(newAnotherService)
+
+	_, err := container.MakeE(new(chainTop))
+
+	var resolutionErr *Container.ResolutionError
+	if !assert.ErrorAs(t, err, &resolutionErr) {
+		return
+	}
+	assert.ErrorIs(t, err, Container.ErrNotBound)
+
+	path := resolutionErr.Path
+	if !assert.Len(t, path, 3) {
+		return
+	}
+
+	assert.Equal(t, reflect.TypeOf(chainTop{}), path[0].Type)
+	assert.Equal(t, "Concrete", path[0].Kind)
+
+	assert.Equal(t, "Middle", path[1].Field)
+	assert.Equal(t, "Function", path[1].Kind)
+
+	assert.Equal(t, reflect.TypeOf((*chainLeaf)(nil)).Elem(), path[2].Type)
+	assert.Equal(t, 1, path[2].ArgIndex)
+	assert.Equal(t, "", path[2].Kind)
+	assert.ErrorIs(t, path[2].Err, Container.ErrNotBound)
+
+	assert.Contains(
+		t,
+		err.Error(),
+		"*tests.chainTop (Concrete) -> field Middle: tests.chainMiddle(iface) (Function) -> arg(1): tests.chainLeaf(iface) (not bound)",
+	)
+}
+
+func TestResolutionErrorHoldsConstructorError(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(newFailingService)
+
+	_, err := container.CallE(func(message string, service serviceAbstract) {}, "message")
+
+	var resolutionErr *Container.ResolutionError
+	if !assert.ErrorAs(t, err, &resolutionErr) {
+		return
+	}
+
+	assert.Len(t, resolutionErr.Path, 1)
+	assert.Equal(t, 1, resolutionErr.Path[0].ArgIndex)
+	assert.Equal(t, "Function", resolutionErr.Path[0].Kind)
+	assert.ErrorIs(t, resolutionErr.Path[0].Err, errConstructor)
+
+	assert.ErrorIs(t, err, Container.ErrConstructorFailed)
+	assert.ErrorIs(t, errors.Unwrap(err), errConstructor)
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -13,7 +14,7 @@ import (
 //
 
 func TestResolvingWithTypeCast(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	if !container.Bind(new(serviceAbstract), &serviceConcrete{}) {
 		t.Fatal("Could not bind serviceAbstract to serviceConcrete")
 	}
@@ -36,7 +37,7 @@ func TestResolvingWithTypeCast(t *testing.T) {
 }
 
 func TestResolvingWithVar(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	if !container.Bind((*serviceAbstract)(nil), serviceConcrete{}) {
 		t.Fatal("Could not bind serviceAbstract to serviceConcrete")
 	}
@@ -61,7 +62,7 @@ func TestResolvingWithVar(t *testing.T) {
 }
 
 func TestResolvingWithProvidedArgs(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	if !container.Bind(newServiceConcreteWithMessageArg) {
 		t.Fatal("Could not bind via newServiceConcreteWithMessageArg func")
 	}
@@ -86,7 +87,7 @@ func TestResolvingWithProvidedArgs(t *testing.T) {
 }
 
 func TestResolvingWithSingleProvidedArgAndRestFromContainer(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	if !container.Bind(newServiceConcreteWithMessageArgAndService) {
 		t.Fatal("Could not bind via newServiceConcreteWithMessageArg func")
 	}
@@ -132,7 +133,7 @@ func (s *SomeOtherStructToResolve) DoThing() {
 	print("")
 }
 func Test_Failed_Resolution(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	container.Bind(func() SomeBullShitServiceContract {
 		return NewSomeBullShitService("big yeet")
 	})
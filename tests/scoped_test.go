@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// SCOPED BINDINGS
+//
+
+type requestContext struct {
+	id int
+}
+
+type requestHandler struct {
+	Context *requestContext
+}
+
+func TestScopedIsCachedPerChildContainer(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	created := 0
+	container.Scoped(func() *requestContext {
+		created++
+		return &requestContext{id: created}
+	})
+
+	first := container.CreateChildContainer()
+	second := container.CreateChildContainer()
+
+	firstContext := Container.MustResolve[*requestContext](first)
+	assert.Same(t, firstContext, Container.MustResolve[*requestContext](first))
+
+	secondContext := Container.MustResolve[*requestContext](second)
+	assert.NotSame(t, firstContext, secondContext)
+	assert.Same(t, secondContext, Container.MustResolve[*requestContext](second))
+
+	assert.Equal(t, 2, created)
+}
+
+func TestScopedIsInjectedFromTheResolvingChild(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Scoped(new(requestContext))
+	container.Bind(new(requestHandler))
+
+	child := container.CreateChildContainer()
+
+	handler := Container.MustResolve[*requestHandler](child)
+	assert.Same(t, Container.MustResolve[*requestContext](child), handler.Context)
+	assert.NotSame(t, Container.MustResolve[*requestContext](container), handler.Context)
+}
+
+func TestClearingChildReleasesScopedInstancesOnly(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Singleton(newServiceConcrete)
+	container.Scoped(new(requestContext))
+
+	child := container.CreateChildContainer()
+
+	singleton := Container.MustResolve[*serviceConcrete](child)
+	scoped := Container.MustResolve[*requestContext](child)
+
+	child.ClearInstances()
+
+	assert.Same(t, singleton, Container.MustResolve[*serviceConcrete](child))
+	assert.NotSame(t, scoped, Container.MustResolve[*requestContext](child))
+}
@@ -0,0 +1,150 @@
+package tests
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// EXACTLY ONCE SINGLETON CONSTRUCTION
+//
+
+type slowSingleton struct{}
+
+type deadlockGate struct{}
+type deadlockA struct{}
+type deadlockB struct{}
+
+func TestSingletonIsConstructedOnceUnderConcurrency(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	var constructed int32
+	container.Singleton(func() *slowSingleton {
+		atomic.AddInt32(&constructed, 1)
+		time.Sleep(20 * time.Millisecond)
+		return &slowSingleton{}
+	})
+
+	instances := make([]*slowSingleton, concurrentWorkers)
+	runConcurrently(concurrentWorkers, func(worker int) {
+		instances[worker] = Container.MustResolve[*slowSingleton](container)
+	})
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&constructed))
+	for _, instance := range instances {
+		assert.Same(t, instances[0], instance)
+	}
+}
+
+func TestSingletonErrorsAreRetriedByDefault(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	calls := 0
+	container.Singleton(func() (*slowSingleton, error) {
+		calls++
+		return nil, errConstructor
+	})
+
+	_, err := Container.Resolve[*slowSingleton](container)
+	assert.ErrorIs(t, err, errConstructor)
+	_, err = Container.Resolve[*slowSingleton](container)
+	assert.ErrorIs(t, err, errConstructor)
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestSingletonErrorsCanBeMemoized(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Config.SingletonErrorPolicy = Container.MemoizeSingletonErrors
+
+	calls := 0
+	container.Singleton(func() (*slowSingleton, error) {
+		calls++
+		return nil, errConstructor
+	})
+
+	_, err := Container.Resolve[*slowSingleton](container)
+	assert.ErrorIs(t, err, errConstructor)
+	_, err = Container.Resolve[*slowSingleton](container)
+	assert.ErrorIs(t, err, errConstructor)
+	assert.Equal(t, 1, calls)
+
+	// ClearInstances forgets the memoized error
+	container.ClearInstances()
+	_, err = Container.Resolve[*slowSingleton](container)
+	assert.ErrorIs(t, err, errConstructor)
+	assert.Equal(t, 2, calls)
+}
+
+func TestSingletonWaitingOnItselfFromAnotherGoroutineTimesOut(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Config.SingletonWaitTimeout = 50 * time.Millisecond
+
+	var innerErr error
+	container.Singleton(func() *slowSingleton {
+		// The constructor waits on a goroutine, which needs the singleton being constructed
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, innerErr = Container.Resolve[*slowSingleton](container)
+		}()
+		<-done
+
+		return &slowSingleton{}
+	})
+
+	_, err := Container.Resolve[*slowSingleton](container)
+
+	assert.NoError(t, err)
+	assert.ErrorIs(t, innerErr, Container.ErrDeadlock)
+}
+
+func TestSingletonsWaitingOnEachOtherAreDetected(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	// Both singletons resolve the gate first, so they're both marked as being constructed
+	// before either of them resolves the other one
+	var gate sync.WaitGroup
+	gate.Add(2)
+	container.Bind(func() *deadlockGate {
+		gate.Done()
+		gate.Wait()
+		return &deadlockGate{}
+	})
+	container.Singleton(func(gate *deadlockGate, b *deadlockB) *deadlockA {
+		return &deadlockA{}
+	})
+	container.Singleton(func(gate *deadlockGate, a *deadlockA) *deadlockB {
+		return &deadlockB{}
+	})
+
+	errs := make([]error, 2)
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		runConcurrently(2, func(worker int) {
+			if worker == 0 {
+				_, errs[0] = Container.Resolve[*deadlockA](container)
+			} else {
+				_, errs[1] = Container.Resolve[*deadlockB](container)
+			}
+		})
+	}()
+
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Resolving singletons which wait on each other deadlocked")
+	}
+
+	assert.Error(t, errs[0])
+	assert.Error(t, errs[1])
+	assert.True(t, errors.Is(errs[0], Container.ErrDeadlock) || errors.Is(errs[1], Container.ErrDeadlock))
+}
@@ -3,7 +3,7 @@ package tests
 import (
 	"testing"
 
-	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
 )
 
 //
@@ -11,20 +11,18 @@ import (
 //
 
 func TestBindingSingletonInstanceToContainer(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 
 	didBind := container.Instance(createSingletonServiceOne())
 	if didBind == false {
 		t.Fatalf("Failed to bind singleton `new(serviceConcrete)` to the container.")
 	}
 
-	if !container.IsBound(new(serviceConcrete)) {
-		t.Fatalf("Singleton `new(serviceConcrete)` is not bound to the container.")
-	}
+	containertest.AssertBound(t, container, new(serviceConcrete))
 }
 
 func TestBindingSingletonTypeAndResolverFuncToContainer(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 
 	didBind := container.Singleton(new(serviceConcrete), createSingletonServiceTwo)
 
@@ -32,13 +30,11 @@ func TestBindingSingletonTypeAndResolverFuncToContainer(t *testing.T) {
 		t.Fatalf("Failed to bind singleton `new(serviceConcrete)` with resolver func to the container.")
 	}
 
-	if !container.IsBound(new(serviceConcrete)) {
-		t.Fatalf("Singleton `new(serviceConcrete)` with resolver func is not bound to the container.")
-	}
+	containertest.AssertBound(t, container, new(serviceConcrete))
 }
 
 func TestBindingSingletonFuncToContainer(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 
 	didBind := container.Singleton(createSingletonServiceThree)
 
@@ -46,9 +42,7 @@ func TestBindingSingletonFuncToContainer(t *testing.T) {
 		t.Fatalf("Failed to bind singleton resolver func for serviceConcrete to the container.")
 	}
 
-	if !container.IsBound(new(serviceConcrete)) {
-		t.Fatalf("Singleton resolver func serviceConcrete is not bound to the container.")
-	}
+	containertest.AssertBound(t, container, new(serviceConcrete))
 }
 
 //
@@ -56,7 +50,7 @@ func TestBindingSingletonFuncToContainer(t *testing.T) {
 //
 
 func TestResolvingSingletonInstance(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	singleton := createSingletonServiceOne()
 	container.Instance(singleton)
 
@@ -87,7 +81,7 @@ func TestResolvingSingletonInstance(t *testing.T) {
 }
 
 func TestResolvingSingletonTypeAndResolverFunc(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	singleton := createSingletonServiceTwo()
 	container.Singleton(new(serviceConcrete), createSingletonServiceTwo)
 
@@ -107,9 +101,10 @@ func TestResolvingSingletonTypeAndResolverFunc(t *testing.T) {
 }
 
 func TestResolvingSingletonFuncFunc(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	singleton := createSingletonServiceThree()
 	container.Singleton(createSingletonServiceThree)
+	containertest.AssertSingleton(t, container, new(serviceConcrete))
 
 	var service *serviceConcrete
 	container.MakeTo(&service)
@@ -0,0 +1,158 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// SWAPPING BINDINGS FOR FAKES
+//
+
+type fakeMailer struct {
+	sent []string
+	name string
+}
+
+func (m *fakeMailer) Send() string {
+	m.sent = append(m.sent, m.name)
+	return m.name
+}
+
+type mailerConsumer struct {
+	Mailer mailer
+}
+
+func TestSwapReplacesAndRestoresBinding(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Singleton(func() mailer { return &smtpMailer{} })
+	container.Bind(new(mailerConsumer))
+	original := Container.MustResolve[mailer](container)
+
+	fake := &fakeMailer{name: "fake"}
+	restore := container.Swap(new(mailer), fake)
+
+	assert.Same(t, fake, Container.MustResolve[mailer](container))
+	assert.Same(t, fake, Container.MustResolve[*mailerConsumer](container).Mailer)
+
+	restore()
+
+	// The previous singleton instance is kept, it isn't created again
+	assert.Same(t, original, Container.MustResolve[mailer](container))
+
+	// Restoring twice does nothing
+	restore()
+	assert.Same(t, original, Container.MustResolve[mailer](container))
+}
+
+func TestSwapEvictsScopedInstancesInChildren(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Scoped(func() mailer { return &fakeMailer{name: "scoped"} })
+
+	child := container.CreateChildContainer()
+	original := Container.MustResolve[mailer](child)
+
+	fake := &fakeMailer{name: "fake"}
+	restore := container.Swap(new(mailer), fake)
+
+	// The child created before the swap doesn't keep using its scoped instance
+	assert.Same(t, fake, Container.MustResolve[mailer](child))
+
+	restore()
+
+	restored := Container.MustResolve[mailer](child)
+	assert.Equal(t, "scoped", restored.Send())
+	assert.NotSame(t, original, restored)
+}
+
+func TestSwapUnboundType(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	restore := container.Swap(new(mailer), &fakeMailer{name: "fake"})
+	assert.Equal(t, "fake", Container.MustResolve[mailer](container).Send())
+
+	restore()
+	containertest.AssertNotBound(t, container, new(mailer))
+	containertest.AssertNotBound(t, container, new(fakeMailer))
+}
+
+func TestSwapWithFunction(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(func() mailer { return &smtpMailer{} })
+
+	restore := container.Swap(new(mailer), func() mailer { return &fakeMailer{name: "fake"} })
+	assert.Equal(t, "fake", Container.MustResolve[mailer](container).Send())
+	assert.NotSame(t, Container.MustResolve[mailer](container), Container.MustResolve[mailer](container))
+
+	restore()
+	assert.Equal(t, "smtp", Container.MustResolve[mailer](container).Send())
+}
+
+func TestNestedSwapsUnwind(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(func() mailer { return &smtpMailer{} })
+
+	restoreOuter := container.Swap(new(mailer), &fakeMailer{name: "outer"})
+	restoreInner := container.Swap(new(mailer), &fakeMailer{name: "inner"})
+	assert.Equal(t, "inner", Container.MustResolve[mailer](container).Send())
+
+	restoreInner()
+	assert.Equal(t, "outer", Container.MustResolve[mailer](container).Send())
+
+	restoreOuter()
+	assert.Equal(t, "smtp", Container.MustResolve[mailer](container).Send())
+}
+
+func TestNestedSwapsRestoredOutOfOrder(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(func() mailer { return &smtpMailer{} })
+
+	restoreOuter := container.Swap(new(mailer), &fakeMailer{name: "outer"})
+	restoreInner := container.Swap(new(mailer), &fakeMailer{name: "inner"})
+
+	restoreOuter()
+	assert.Equal(t, "inner", Container.MustResolve[mailer](container).Send())
+
+	restoreInner()
+	assert.Equal(t, "smtp", Container.MustResolve[mailer](container).Send())
+}
+
+func TestFakeWithCleanup(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(func() mailer { return &smtpMailer{} })
+
+	t.Run("faked", func(t *testing.T) {
+		fake := &fakeMailer{name: "fake"}
+		t.Cleanup(Container.Fake[mailer](container, fake))
+
+		Container.MustResolve[mailer](container).Send()
+		assert.Equal(t, []string{"fake"}, fake.sent)
+	})
+
+	assert.Equal(t, "smtp", Container.MustResolve[mailer](container).Send())
+}
+
+func TestSwapRejectsInvalidFakes(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	_, err := container.SwapE(new(mailer), &localStorage{})
+	assert.True(t, errors.Is(err, Container.ErrInvalidBinding))
+
+	// A function returning another interface is only checked when it's resolved, the same as Bind
+	restore, err := container.SwapE(new(mailer), func() storage { return &localStorage{} })
+	if assert.NoError(t, err) {
+		_, err = Container.Resolve[mailer](container)
+		assert.True(t, errors.Is(err, Container.ErrInvalidBinding))
+		restore()
+	}
+
+	_, err = container.SwapE(new(mailer), nil)
+	assert.True(t, errors.Is(err, Container.ErrInvalidBinding))
+
+	// Swap logs the error, the restore func can still be called
+	container.Swap(new(mailer), nil)()
+}
@@ -1,9 +1,12 @@
 package tests
 
 import (
+	"errors"
 	"testing"
 
 	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
 )
 
 //
@@ -11,33 +14,175 @@ import (
 //
 
 func TestAddingTaggedBindings(t *testing.T) {
-	container := Container.CreateContainer()
+	container := containertest.NewIsolated(t)
 	container.Bind(newServiceConcrete)
 	container.Bind(newServiceConcreteTwo)
 
-	if !container.IsBound(new(serviceConcrete)) || !container.IsBound(new(serviceConcreteTwo)) {
-		t.Fatal("Could not find bindings in container")
-	}
+	containertest.AssertBound(t, container, new(serviceConcrete))
+	containertest.AssertBound(t, container, new(serviceConcreteTwo))
 
 	didTag := container.Tag("SingletonServices", new(serviceConcrete), new(serviceConcreteTwo))
 	if !didTag {
 		t.Fatal("Failed to tag services")
 	}
 
-	tagged := container.Tagged("SingletonServices")
+	containertest.AssertTagged(t, container, "SingletonServices", new(serviceConcrete), new(serviceConcreteTwo))
+}
 
-	if len(tagged) == 0 {
-		t.Fatal("Failed to get tagged services")
-	}
-	if len(tagged) != 2 {
-		t.Fatalf("We should only have two tagged services, we have %d", len(tagged))
-	}
+type healthDashboard struct {
+	checks []healthCheck
+}
 
-	if _, ok := tagged[0].(*serviceConcrete); !ok {
-		t.Fatal("First tagged service is not serviceConcrete")
-	}
+func newHealthDashboard(checks []healthCheck) *healthDashboard {
+	return &healthDashboard{checks: checks}
+}
 
-	if _, ok := tagged[1].(*serviceConcreteTwo); !ok {
-		t.Fatal("First tagged service is not serviceConcreteTwo")
-	}
+type taggedHealthReport struct {
+	Checks []healthCheck `inject:"tagged=HealthChecks"`
+}
+
+func bindTaggedHealthChecks(container *Container.ContainerInstance) {
+	container.Bind(new(databaseCheck))
+	container.Bind(new(cacheCheck))
+	container.Tag("HealthChecks", new(databaseCheck), new(cacheCheck))
+}
+
+func TestInjectTaggedConstructorArg(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	bindTaggedHealthChecks(container)
+
+	assert.NoError(t, container.BindE(newHealthDashboard, Container.InjectTagged[healthCheck]("HealthChecks")))
+
+	dashboard := Container.MustResolve[*healthDashboard](container)
+	assert.Equal(t, []string{"database", "cache"}, checkNames(dashboard.checks))
+}
+
+func TestInjectTaggedCallAndMakeArgs(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	bindTaggedHealthChecks(container)
+	container.Bind(new(diskCheck))
+	container.Tag("DiskChecks", new(diskCheck))
+
+	results, err := container.CallE(func(checks []healthCheck) []string {
+		return checkNames(checks)
+	}, Container.InjectTagged[healthCheck]("HealthChecks"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"database", "cache"}, results[0])
+
+	// InjectTagged passed to Make overrides the binding's
+	container.Bind(newHealthDashboard, Container.InjectTagged[healthCheck]("HealthChecks"))
+	dashboard, err := container.MakeE(new(healthDashboard), Container.InjectTagged[healthCheck]("DiskChecks"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"disk"}, checkNames(dashboard.(*healthDashboard).checks))
+}
+
+func TestInjectTaggedStructField(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	bindTaggedHealthChecks(container)
+	container.Bind(new(taggedHealthReport))
+
+	report := Container.MustResolve[*taggedHealthReport](container)
+	assert.Equal(t, []string{"database", "cache"}, checkNames(report.Checks))
+}
+
+func TestInjectTaggedRejectsElementsOfTheWrongType(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	bindTaggedHealthChecks(container)
+	container.Bind(newServiceConcrete)
+	container.Tag("HealthChecks", new(serviceConcrete))
+	container.Bind(newHealthDashboard, Container.InjectTagged[healthCheck]("HealthChecks"))
+	container.Bind(new(taggedHealthReport))
+
+	_, err := Container.Resolve[*healthDashboard](container)
+	assert.True(t, errors.Is(err, Container.ErrInvalidTarget))
+	assert.Contains(t, err.Error(), "binding tagged with HealthChecks is not a tests.healthCheck")
+
+	_, err = Container.Resolve[*taggedHealthReport](container)
+	assert.True(t, errors.Is(err, Container.ErrInvalidTarget))
+}
+
+func TestInjectTaggedInvalidBindings(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	err := container.BindE(newHealthDashboard, Container.InjectTagged[storage]("HealthChecks"))
+	assert.True(t, errors.Is(err, Container.ErrInvalidBinding))
+
+	err = container.BindE(new(taggedHealthReport), Container.InjectTagged[healthCheck]("HealthChecks"))
+	assert.True(t, errors.Is(err, Container.ErrInvalidBinding))
+
+	err = container.SingletonE(newHealthDashboard, Container.InjectTagged[healthCheck](""))
+	assert.True(t, errors.Is(err, Container.ErrInvalidBinding))
+}
+
+func TestChildTaggedIncludesParentTags(t *testing.T) {
+	parent := containertest.NewIsolated(t)
+	bindTaggedHealthChecks(parent)
+
+	child := parent.CreateChildContainer()
+	child.Bind(new(diskCheck))
+	assert.True(t, child.Tag("HealthChecks", new(diskCheck), new(databaseCheck)))
+
+	checks, err := Container.TaggedOf[healthCheck](child, "HealthChecks")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"database", "cache", "disk"}, checkNames(checks))
+
+	// The parent doesn't see the child's tags
+	checks, err = Container.TaggedOf[healthCheck](parent, "HealthChecks")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"database", "cache"}, checkNames(checks))
+}
+
+func TestChildTaggedResolvesInTheChild(t *testing.T) {
+	parent := containertest.NewIsolated(t)
+	parent.Scoped(new(databaseCheck))
+	parent.Bind(func() healthCheck { return &cacheCheck{} })
+	parent.Tag("HealthChecks", new(databaseCheck), new(healthCheck))
+
+	child := parent.CreateChildContainer()
+	child.Bind(func() healthCheck { return &diskCheck{} })
+	child.Bind(newHealthDashboard, Container.InjectTagged[healthCheck]("HealthChecks"))
+
+	dashboard := Container.MustResolve[*healthDashboard](child)
+	assert.Equal(t, []string{"database", "disk"}, checkNames(dashboard.checks))
+	assert.Same(t, Container.MustResolve[*databaseCheck](child), dashboard.checks[0])
+	assert.NotSame(t, Container.MustResolve[*databaseCheck](parent), dashboard.checks[0])
+}
+
+func TestUntagInChildContainer(t *testing.T) {
+	parent := containertest.NewIsolated(t)
+	bindTaggedHealthChecks(parent)
+
+	child := parent.CreateChildContainer()
+	assert.True(t, child.Untag("HealthChecks", new(databaseCheck)))
+
+	checks, _ := Container.TaggedOf[healthCheck](child, "HealthChecks")
+	assert.Equal(t, []string{"cache"}, checkNames(checks))
+
+	checks, _ = Container.TaggedOf[healthCheck](parent, "HealthChecks")
+	assert.Equal(t, []string{"database", "cache"}, checkNames(checks))
+
+	// Tagging it again in the child adds it back
+	child.Tag("HealthChecks", new(databaseCheck))
+	checks, _ = Container.TaggedOf[healthCheck](child, "HealthChecks")
+	assert.Equal(t, []string{"cache", "database"}, checkNames(checks))
+
+	assert.False(t, child.Untag("HealthChecks", new(storage)))
+}
+
+func TestUntagWholeTag(t *testing.T) {
+	parent := containertest.NewIsolated(t)
+	bindTaggedHealthChecks(parent)
+
+	child := parent.CreateChildContainer()
+	grandchild := child.CreateChildContainer()
+	assert.True(t, child.Untag("HealthChecks"))
+
+	assert.Empty(t, child.Tagged("HealthChecks"))
+	assert.Empty(t, grandchild.Tagged("HealthChecks"))
+	assert.Len(t, parent.Tagged("HealthChecks"), 2)
+
+	grandchild.Bind(new(diskCheck))
+	grandchild.Tag("HealthChecks", new(diskCheck))
+	checks, _ := Container.TaggedOf[healthCheck](grandchild, "HealthChecks")
+	assert.Equal(t, []string{"disk"}, checkNames(checks))
 }
@@ -0,0 +1,253 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	Container "github.com/Envuso/go-ioc-container"
+	"github.com/Envuso/go-ioc-container/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+// VALIDATING THE DEPENDENCY GRAPH
+//
+
+type checkoutService struct {
+	Payments  paymentGateway `inject:""`
+	Inventory *inventoryStore
+	Mailer    mailer `inject:"optional"`
+}
+
+type paymentGateway interface {
+	Charge(amount int) error
+}
+
+type stripeGateway struct {
+	client *httpClient
+}
+
+func (g *stripeGateway) Charge(amount int) error {
+	return nil
+}
+
+type httpClient struct {
+	timeout int
+}
+
+type inventoryStore struct {
+	items int
+}
+
+func newStripeGateway(client *httpClient) *stripeGateway {
+	return &stripeGateway{client: client}
+}
+
+// validationProblems - The problems in the ValidationError err, fails the test if it isn't one
+func validationProblems(t *testing.T, err error) []error {
+	t.Helper()
+
+	var validationErr *Container.ValidationError
+	if !assert.True(t, errors.As(err, &validationErr)) {
+		return nil
+	}
+
+	return validationErr.Problems
+}
+
+func TestValidatePassesWithoutConstructingAnything(t *testing.T) {
+	container := containertest.NewIsolated(t)
+
+	constructed := 0
+	container.Singleton(new(paymentGateway), func(client *httpClient) *stripeGateway {
+		constructed++
+		return newStripeGateway(client)
+	})
+	container.Bind(func() *httpClient {
+		constructed++
+		return &httpClient{timeout: 30}
+	})
+	container.Bind(new(inventoryStore))
+	container.Bind(new(checkoutService))
+
+	assert.NoError(t, container.Validate())
+	assert.Equal(t, 0, constructed)
+}
+
+func TestValidateReportsEveryMissingDependency(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(new(paymentGateway), newStripeGateway)
+	container.Bind(new(checkoutService))
+	container.Bind(newReplicaReport)
+
+	err := container.Validate()
+	assert.True(t, errors.Is(err, Container.ErrNotBound))
+
+	problems := validationProblems(t, err)
+	if assert.Len(t, problems, 2) {
+		// The field without a tag isn't required, so only the gateway's client is missing for checkoutService
+		// The gateway is only walked once, so its missing client isn't reported again
+		assert.Contains(
+			t,
+			problems[0].Error(),
+			"*tests.checkoutService (Concrete) -> field Payments: tests.paymentGateway(iface) (Abstract) -> arg(0): *tests.httpClient (not bound)",
+		)
+		assert.Contains(t, problems[1].Error(), "*tests.replicaReport (Function) -> arg(0): tests.database(iface) (not bound)")
+	}
+
+	var resolutionErr *Container.ResolutionError
+	if assert.True(t, errors.As(problems[0], &resolutionErr)) {
+		assert.Len(t, resolutionErr.Path, 3)
+	}
+}
+
+func TestValidateReportsMissingNamedTaggedAndLazyDependencies(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(new(databaseReport))
+	container.Bind(newPrimaryDatabase)
+	container.Bind(newHealthDashboard, Container.InjectTagged[healthCheck]("HealthChecks"))
+	container.Bind(new(databaseCheck))
+	container.Bind(func(client *httpClient) *diskCheck { return &diskCheck{} })
+	container.Tag("HealthChecks", new(databaseCheck), new(diskCheck))
+	container.Bind(new(lazyReport))
+
+	problems := validationProblems(t, container.Validate())
+	if assert.Len(t, problems, 3) {
+		assert.Contains(t, problems[0].Error(), `field Replica: "replica" tests.database(iface) (not bound)`)
+		assert.Contains(t, problems[1].Error(), "*tests.diskCheck (Function) -> arg(0): *tests.httpClient (not bound)")
+		assert.Contains(t, problems[2].Error(), "field Service: tests.serviceAbstract(iface) (not bound)")
+	}
+}
+
+func TestValidateReportsCycles(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(new(circularA))
+	container.Bind(newCircularB)
+
+	err := container.Validate()
+	assert.True(t, errors.Is(err, Container.ErrCircularDependency))
+
+	problems := validationProblems(t, err)
+	if assert.Len(t, problems, 1) {
+		assert.Contains(t, problems[0].Error(), "*tests.circularA -> tests.circularB(iface) -> *tests.circularA")
+	}
+}
+
+func TestValidateAllowsLazyToBreakCycles(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(new(lazyParent))
+	container.Bind(new(lazyChild))
+
+	assert.NoError(t, container.Validate())
+}
+
+func TestValidateReportsSingletonDependingOnScoped(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Scoped(new(requestContext))
+	container.Bind(new(requestHandler))
+	container.Singleton(func(handler *requestHandler) *inventoryStore {
+		return &inventoryStore{}
+	})
+
+	err := container.Validate()
+	assert.True(t, errors.Is(err, Container.ErrLifetimeViolation))
+
+	problems := validationProblems(t, err)
+	if assert.Len(t, problems, 1) {
+		assert.Contains(
+			t,
+			problems[0].Error(),
+			"*tests.inventoryStore (Singleton) -> arg(0): *tests.requestHandler (Concrete) -> field Context: *tests.requestContext (Scoped)",
+		)
+		assert.Contains(t, problems[0].Error(), "singleton *tests.inventoryStore depends on scoped *tests.requestContext")
+	}
+
+	// A transient binding can depend on scoped ones
+	container.Forget(new(inventoryStore))
+	assert.NoError(t, container.Validate())
+}
+
+func TestValidateReportsAmbiguousConcrete(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(new(serviceAbstract), newServiceConcrete)
+	container.Bind(new(anotherServiceAbstract), newServiceConcrete)
+	container.Bind(func(service serviceConcrete) *inventoryStore {
+		return &inventoryStore{}
+	})
+
+	err := container.Validate()
+	assert.True(t, errors.Is(err, Container.ErrAmbiguousBinding))
+
+	problems := validationProblems(t, err)
+	if assert.Len(t, problems, 1) {
+		assert.Contains(t, problems[0].Error(), "it's bound to tests.anotherServiceAbstract(iface), tests.serviceAbstract(iface)")
+	}
+}
+
+func TestValidateChildContainer(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Singleton(new(paymentGateway), newStripeGateway)
+	container.Bind(new(checkoutService))
+
+	child := container.CreateChildContainer()
+	child.Bind(new(inventoryStore))
+	child.Bind(new(httpClient))
+
+	// The singleton is resolved by the root container, where the client isn't bound
+	problems := validationProblems(t, child.Validate())
+	if assert.Len(t, problems, 1) {
+		assert.Contains(t, problems[0].Error(), "arg(0): *tests.httpClient (not bound)")
+	}
+
+	container.Bind(new(httpClient))
+	assert.NoError(t, child.Validate())
+}
+
+func TestValidateChecksMultiAndContextualBindings(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.BindMany(new(paymentGateway), newStripeGateway)
+	container.When(new(checkoutService)).Needs(new(mailer)).Give(func(client *httpClient) *smtpMailer {
+		return &smtpMailer{}
+	})
+
+	problems := validationProblems(t, container.Validate())
+	if assert.Len(t, problems, 2) {
+		assert.Contains(t, problems[0].Error(), "tests.mailer(iface) (Function) -> arg(0): *tests.httpClient (not bound)")
+		assert.Contains(t, problems[1].Error(), "tests.paymentGateway(iface) (Function) -> arg(0): *tests.httpClient (not bound)")
+	}
+}
+
+func TestValidateSkipsArgsPassedAsParameters(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(newServiceConcreteWithMessageArgAndService)
+
+	// The message is passed to Make, but the service has to be bound
+	problems := validationProblems(t, container.Validate())
+	if assert.Len(t, problems, 1) {
+		assert.Contains(t, problems[0].Error(), "arg(1): tests.anotherServiceAbstract(iface) (not bound)")
+	}
+
+	container.Bind(newAnotherService)
+	assert.NoError(t, container.Validate())
+
+	service, err := container.MakeE(new(serviceAbstract), "hi")
+	assert.NoError(t, err)
+	assert.Equal(t, "hi", service.(serviceAbstract).Message())
+}
+
+func TestValidateChecksBoundParameterTypes(t *testing.T) {
+	container := containertest.NewIsolated(t)
+	container.Bind(newServiceConcreteWithMessageArg)
+	container.Bind(func(limits map[string]int) *inventoryStore {
+		return &inventoryStore{items: limits["items"]}
+	})
+	container.Bind(func(client *httpClient) map[string]int {
+		return map[string]int{"items": client.timeout}
+	})
+
+	// A bound map is resolved from the container, so its own dependencies are checked
+	problems := validationProblems(t, container.Validate())
+	if assert.Len(t, problems, 1) {
+		assert.Contains(t, problems[0].Error(), "map[string]int (Function) -> arg(0): *tests.httpClient (not bound)")
+	}
+}
@@ -32,6 +32,15 @@ func indirectType(typ reflect.Type) reflect.Type {
 	return typ
 }
 
+// pointerElemType - The same as indirectType, but only pointers are de-referenced
+// Slices etc. are returned as is, so a []T is never mistaken for a T
+func pointerElemType(typ reflect.Type) reflect.Type {
+	if typ.Kind() == reflect.Ptr {
+		return typ.Elem()
+	}
+	return typ
+}
+
 // getType - Allows us to get the type if it's not already a type. If it is a type...
 // return it, just prevents us constantly doing .TypeOf() or this everywhere
 func getType(t any) reflect.Type {
@@ -69,6 +78,14 @@ func getAbstractReturnType(abstractType reflect.Type) reflect.Type {
 	return abstract
 }
 
+// hasUnhandledReturns - Check if the function returns more than its value, other than an error
+// (T) & (T, error) are both handled, anything after T is ignored for other functions
+func hasUnhandledReturns(function reflect.Type) bool {
+	numOut := function.NumOut()
+
+	return numOut > 2 || (numOut == 2 && function.Out(1) != errorType)
+}
+
 // getConcreteReturnType - Allows us to pass a function and get it's first
 // return arg or pass a struct and get the type of that
 func getConcreteReturnType(concrete reflect.Type) reflect.Type {
@@ -84,7 +101,7 @@ func getConcreteReturnType(concrete reflect.Type) reflect.Type {
 			log.Printf("Trying to get function return type for binding but it doesnt have a return type...")
 			return nil
 		}
-		if numOut > 1 {
+		if hasUnhandledReturns(concrete) {
 			log.Printf("Getting a function return type, but the function has > 1 return args. Only the first arg is handled.")
 		}
 
@@ -101,3 +118,26 @@ func getConcreteReturnType(concrete reflect.Type) reflect.Type {
 
 	return nil
 }
+
+// containsType - Check if types contains typ
+func containsType(types []reflect.Type, typ reflect.Type) bool {
+	for _, t := range types {
+		if t == typ {
+			return true
+		}
+	}
+
+	return false
+}
+
+// removeType - Returns types without typ, types isn't modified
+func removeType(types []reflect.Type, typ reflect.Type) []reflect.Type {
+	var remaining []reflect.Type
+	for _, t := range types {
+		if t != typ {
+			remaining = append(remaining, t)
+		}
+	}
+
+	return remaining
+}